@@ -0,0 +1,110 @@
+package matchr
+
+import "sort"
+
+// DistanceFunc measures how far apart two strings are. Levenshtein, OSA and
+// DamerauLevenshtein all satisfy it.
+type DistanceFunc func(s1 string, s2 string) int
+
+// BKTree indexes a dictionary of strings for "all entries within distance k
+// of a query" lookups under an integer metric, so that most of the
+// dictionary never has to be compared against the query at all.
+//
+// Each node keeps its children by their distance to it. Since the distance
+// obeys the triangle inequality, a query at distance d from a node can only
+// have matches in the children whose distance to the node lies within
+// [d-k, d+k]. The distance function must therefore be a true metric:
+// Levenshtein and DamerauLevenshtein are, but OSA does not always satisfy
+// the triangle inequality, so some OSA matches can be missed.
+//
+// See https://en.wikipedia.org/wiki/BK-tree for more information.
+type BKTree struct {
+	distance DistanceFunc
+	root     *bkNode
+	size     int
+}
+
+type bkNode struct {
+	term     string
+	children map[int]*bkNode
+}
+
+// BKMatch is one dictionary entry found by BKTree.Search.
+type BKMatch struct {
+	Term     string
+	Distance int
+}
+
+// NewBKTree returns an empty BKTree that measures distance with the given
+// function.
+func NewBKTree(distance DistanceFunc) *BKTree {
+	return &BKTree{distance: distance}
+}
+
+// Len returns the number of distinct terms in the tree.
+func (t *BKTree) Len() int {
+	return t.size
+}
+
+// Add inserts a term into the tree. Adding a term that is already present
+// does nothing.
+func (t *BKTree) Add(term string) {
+	if t.root == nil {
+		t.root = &bkNode{term: term}
+		t.size++
+		return
+	}
+
+	node := t.root
+	for {
+		d := t.distance(term, node.term)
+		if d == 0 {
+			return
+		}
+
+		child, ok := node.children[d]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[int]*bkNode)
+			}
+			node.children[d] = &bkNode{term: term}
+			t.size++
+			return
+		}
+		node = child
+	}
+}
+
+// Search returns every term within distance k of the query, closest first
+// and alphabetically among terms at the same distance.
+func (t *BKTree) Search(query string, k int) (matches []BKMatch) {
+	if t.root == nil || k < 0 {
+		return
+	}
+
+	stack := []*bkNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := t.distance(query, node.term)
+		if d <= k {
+			matches = append(matches, BKMatch{Term: node.term, Distance: d})
+		}
+
+		for cd, child := range node.children {
+			if cd >= d-k && cd <= d+k {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Term < matches[j].Term
+	})
+
+	return
+}
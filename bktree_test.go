@@ -0,0 +1,75 @@
+package matchr
+
+import (
+	"reflect"
+	"testing"
+)
+
+var bkwords = []string{
+	"book", "books", "cake", "boo", "boon", "cook", "cape", "cart",
+	"Schüßler", "Schübler", "Schüler", "library", "librari", "librayr",
+}
+
+var bktreetests = []struct {
+	query   string
+	k       int
+	matches []BKMatch
+}{
+	{"book", 0, []BKMatch{{"book", 0}}},
+	{"book", 1, []BKMatch{{"book", 0}, {"boo", 1}, {"books", 1}, {"boon", 1}, {"cook", 1}}},
+	{"caqe", 1, []BKMatch{{"cake", 1}, {"cape", 1}}},
+	{"Schüßler", 1, []BKMatch{{"Schüßler", 0}, {"Schübler", 1}, {"Schüler", 1}}},
+	{"zzzzzz", 2, nil},
+	{"book", -1, nil},
+}
+
+func TestBKTree(t *testing.T) {
+	tree := NewBKTree(Levenshtein)
+	for _, w := range bkwords {
+		tree.Add(w)
+	}
+	tree.Add("book")
+
+	if tree.Len() != len(bkwords) {
+		t.Errorf("BKTree.Len() = %v, want %v", tree.Len(), len(bkwords))
+	}
+
+	for _, tt := range bktreetests {
+		matches := tree.Search(tt.query, tt.k)
+		if !reflect.DeepEqual(matches, tt.matches) {
+			t.Errorf("BKTree.Search('%s', %d) = %v, want %v", tt.query, tt.k, matches, tt.matches)
+		}
+	}
+}
+
+// The tree must find exactly what comparing against every word would.
+func TestBKTreeExhaustive(t *testing.T) {
+	for _, f := range []DistanceFunc{Levenshtein, DamerauLevenshtein} {
+		tree := NewBKTree(f)
+		for _, w := range bkwords {
+			tree.Add(w)
+		}
+
+		for _, q := range bkwords {
+			for k := 0; k <= 3; k++ {
+				want := 0
+				for _, w := range bkwords {
+					if f(q, w) <= k {
+						want++
+					}
+				}
+
+				if got := len(tree.Search(q, k)); got != want {
+					t.Errorf("BKTree.Search('%s', %d) found %v terms, want %v", q, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBKTreeEmpty(t *testing.T) {
+	tree := NewBKTree(Levenshtein)
+	if matches := tree.Search("book", 2); matches != nil {
+		t.Errorf("BKTree.Search on an empty tree = %v, want nil", matches)
+	}
+}
@@ -0,0 +1,63 @@
+package matchr
+
+// CostModel describes how much each kind of edit costs in the weighted
+// edit-distance functions (LevenshteinWeighted, OSAWeighted and
+// DamerauLevenshteinWeighted). The flat costs apply to every rune; the
+// optional callbacks, when set, take precedence and allow costs to vary by
+// rune, e.g. to make a substitution between keyboard-adjacent keys or
+// OCR-confusable glyphs (0/O, 1/l) cheaper than any other.
+//
+// Matching runes never cost anything, so SubstituteFunc is only consulted
+// for two different runes.
+type CostModel struct {
+	Insert     float64
+	Delete     float64
+	Substitute float64
+	Transpose  float64
+
+	InsertFunc     func(r rune) float64
+	DeleteFunc     func(r rune) float64
+	SubstituteFunc func(a, b rune) float64
+	TransposeFunc  func(a, b rune) float64
+}
+
+// UnitCosts returns the cost model used by the unweighted functions, in
+// which every step in the transformation "costs" one distance point.
+func UnitCosts() CostModel {
+	return CostModel{Insert: 1, Delete: 1, Substitute: 1, Transpose: 1}
+}
+
+// cost of inserting r
+func (c *CostModel) insert(r rune) float64 {
+	if c.InsertFunc != nil {
+		return c.InsertFunc(r)
+	}
+	return c.Insert
+}
+
+// cost of deleting r
+func (c *CostModel) delete(r rune) float64 {
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(r)
+	}
+	return c.Delete
+}
+
+// cost of replacing a with b, which is free when they are the same
+func (c *CostModel) substitute(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	if c.SubstituteFunc != nil {
+		return c.SubstituteFunc(a, b)
+	}
+	return c.Substitute
+}
+
+// cost of swapping the adjacent runes a and b
+func (c *CostModel) transpose(a, b rune) float64 {
+	if c.TransposeFunc != nil {
+		return c.TransposeFunc(a, b)
+	}
+	return c.Transpose
+}
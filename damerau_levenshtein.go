@@ -6,7 +6,9 @@ package matchr
 // string (s1) into another (s2). Each step in the transformation "costs"
 // one distance point. It is similar to the Optimal String Alignment,
 // algorithm, but is more complex because it allows multiple edits on
-// substrings.
+// substrings. Only one row of the distance table is kept for each distinct
+// rune of s1, so memory grows with the size of that alphabet rather than
+// with the length of s1.
 //
 // This implementation is based off of the one found on Wikipedia at
 // http://en.wikipedia.org/wiki/Damerau%E2%80%93Levenshtein_distance#Distance_with_adjacent_transpositions
@@ -17,6 +19,38 @@ func DamerauLevenshtein(s1 string, s2 string) (distance int) {
 	r1 := []rune(s1)
 	r2 := []rune(s2)
 
+	seenRunes := make(map[rune]int)
+	rows := make([]int, damerauLevenshteinRows(r1, seenRunes)*(len(r2)+1))
+
+	return damerauLevenshtein(r1, r2, rows, seenRunes)
+}
+
+// damerauLevenshteinRows gives each distinct rune of r1 a row of its own
+// after the two that damerauLevenshtein computes in, records the row number
+// in seenRunes, which must be empty, and returns how many rows there are.
+func damerauLevenshteinRows(r1 []rune, seenRunes map[rune]int) int {
+	n := 2
+	for _, r := range r1 {
+		if _, ok := seenRunes[r]; !ok {
+			seenRunes[r] = n
+			n++
+		}
+	}
+	return n
+}
+
+// damerauLevenshtein computes the Damerau-Levenshtein distance between two
+// rune slices. A transposition only ever looks back at the row of the
+// edit-tracking matrix just above the last place the rune from r2 was seen
+// in r1, so rather than the whole matrix, only the previous and current
+// rows are kept, plus that one row for each distinct rune of r1.
+//
+// rows holds those rows, each len(r2)+1 values wide, and seenRunes the row
+// each rune is kept in, as set up by damerauLevenshteinRows. The first value
+// of a rune's row is one more than the last position it was seen at in r1,
+// or zero if it has not been seen yet; the rest is the matrix row. rows must
+// be all zero to begin with.
+func damerauLevenshtein(r1 []rune, r2 []rune, rows []int, seenRunes map[rune]int) (distance int) {
 	// the maximum possible distance
 	inf := len(r1) + len(r2)
 
@@ -30,35 +64,28 @@ func DamerauLevenshtein(s1 string, s2 string) (distance int) {
 		return len(r1)
 	}
 
-	// construct the edit-tracking matrix
-	matrix := make([][]int, len(r1))
-	for i := range matrix {
-		matrix[i] = make([]int, len(r2))
-	}
+	width := len(r2) + 1
 
-	// seen characters
-	seenRunes := make(map[rune]int)
+	// the row above and the one being filled in
+	prev := rows[1:width]
+	curr := rows[width+1 : 2*width]
 
-	if r1[0] != r2[0] {
-		matrix[0][0] = 1
+	// remember that r1[i] was seen at i, along with the row above it
+	see := func(i int, above []int) {
+		n := seenRunes[r1[i]] * width
+		rows[n] = i + 1
+		copy(rows[n+1:n+width], above)
 	}
 
-	seenRunes[r1[0]] = 0
-	for i := 1; i < len(r1); i++ {
-		deleteDist := matrix[i-1][0] + 1
-		insertDist := (i+1)*1 + 1
-		var matchDist int
-		if r1[i] == r2[0] {
-			matchDist = i
-		} else {
-			matchDist = i + 1
-		}
-		matrix[i][0] = min(min(deleteDist, insertDist), matchDist)
+	if r1[0] != r2[0] {
+		curr[0] = 1
+	} else {
+		curr[0] = 0
 	}
 
 	for j := 1; j < len(r2); j++ {
 		deleteDist := (j + 1) * 2
-		insertDist := matrix[0][j-1] + 1
+		insertDist := curr[j-1] + 1
 		var matchDist int
 		if r1[0] == r2[j] {
 			matchDist = j
@@ -66,10 +93,25 @@ func DamerauLevenshtein(s1 string, s2 string) (distance int) {
 			matchDist = j + 1
 		}
 
-		matrix[0][j] = min(min(deleteDist, insertDist), matchDist)
+		curr[j] = min(min(deleteDist, insertDist), matchDist)
 	}
 
+	// there is no row above the first, so it stands in for one
+	see(0, curr)
+
 	for i := 1; i < len(r1); i++ {
+		prev, curr = curr, prev
+
+		deleteDist := prev[0] + 1
+		insertDist := (i+1)*1 + 1
+		var matchDist int
+		if r1[i] == r2[0] {
+			matchDist = i
+		} else {
+			matchDist = i + 1
+		}
+		curr[0] = min(min(deleteDist, insertDist), matchDist)
+
 		var maxSrcMatchIndex int
 		if r1[i] == r2[0] {
 			maxSrcMatchIndex = 0
@@ -78,11 +120,12 @@ func DamerauLevenshtein(s1 string, s2 string) (distance int) {
 		}
 
 		for j := 1; j < len(r2); j++ {
-			swapIndex, ok := seenRunes[r2[j]]
+			swapRow, ok := seenRunes[r2[j]]
+			ok = ok && rows[swapRow*width] > 0
 			jSwap := maxSrcMatchIndex
-			deleteDist := matrix[i-1][j] + 1
-			insertDist := matrix[i][j-1] + 1
-			matchDist := matrix[i-1][j-1]
+			deleteDist := prev[j] + 1
+			insertDist := curr[j-1] + 1
+			matchDist := prev[j-1]
 			if r1[i] != r2[j] {
 				matchDist += 1
 			} else {
@@ -92,21 +135,103 @@ func DamerauLevenshtein(s1 string, s2 string) (distance int) {
 			// for transpositions
 			var swapDist int
 			if ok && jSwap != -1 {
-				iSwap := swapIndex
+				iSwap := rows[swapRow*width] - 1
 				var preSwapCost int
 				if iSwap == 0 && jSwap == 0 {
 					preSwapCost = 0
 				} else {
-					preSwapCost = matrix[maxI(0, iSwap-1)][maxI(0, jSwap-1)]
+					preSwapCost = rows[(swapRow*width)+1+maxI(0, jSwap-1)]
 				}
 				swapDist = i + j + preSwapCost - iSwap - jSwap - 1
 			} else {
 				swapDist = inf
 			}
-			matrix[i][j] = min(min(min(deleteDist, insertDist), matchDist), swapDist)
+			curr[j] = min(min(min(deleteDist, insertDist), matchDist), swapDist)
+		}
+		see(i, prev)
+	}
+
+	return curr[len(r2)-1]
+}
+
+// DamerauLevenshteinWeighted computes the Damerau-Levenshtein distance
+// between two strings using the given cost model instead of charging one
+// distance point for every step. With UnitCosts() it returns the same value
+// as DamerauLevenshtein.
+//
+// This is the Lowrance-Wagner formulation of the algorithm, which is only
+// guaranteed to find the cheapest transformation when twice the cost of a
+// transposition is at least the cost of an insertion plus a deletion.
+func DamerauLevenshteinWeighted(s1 string, s2 string, costs CostModel) (distance float64) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	dist := damerauLevenshteinMatrix(r1, r2, &costs)
+	distance = dist[len(dist)-1]
+
+	return
+}
+
+// damerauLevenshteinMatrix fills in the full (len(r1)+1) x (len(r2)+1)
+// Damerau-Levenshtein distance table, stored row by row, under the given
+// cost model.
+func damerauLevenshteinMatrix(r1 []rune, r2 []rune, costs *CostModel) []float64 {
+	rows := len(r1) + 1
+	cols := len(r2) + 1
+
+	// running totals of deleting the first i runes of r1 and inserting the
+	// first j runes of r2, used to price the edits between transposed runes
+	delSum := make([]float64, rows)
+	for i := 1; i < rows; i++ {
+		delSum[i] = delSum[i-1] + costs.delete(r1[i-1])
+	}
+
+	insSum := make([]float64, cols)
+	for j := 1; j < cols; j++ {
+		insSum[j] = insSum[j-1] + costs.insert(r2[j-1])
+	}
+
+	dist := make([]float64, rows*cols)
+	for i := 1; i < rows; i++ {
+		dist[i*cols] = delSum[i]
+	}
+	for j := 1; j < cols; j++ {
+		dist[j] = insSum[j]
+	}
+
+	// the last row in which each rune of r1 was seen
+	seenRunes := make(map[rune]int)
+
+	for i := 1; i < rows; i++ {
+		// the last column in this row whose rune matched r1[i-1]
+		lastMatch := 0
+		for j := 1; j < cols; j++ {
+			k := seenRunes[r2[j-1]]
+			l := lastMatch
+
+			d := minF(
+				minF(dist[((i-1)*cols)+j]+costs.delete(r1[i-1]),
+					dist[(i*cols)+(j-1)]+costs.insert(r2[j-1])),
+				dist[((i-1)*cols)+(j-1)]+costs.substitute(r1[i-1], r2[j-1]))
+
+			if r1[i-1] == r2[j-1] {
+				lastMatch = j
+			}
+
+			// for transpositions
+			if k > 0 && l > 0 {
+				swapDist := dist[((k-1)*cols)+(l-1)] +
+					(delSum[i-1] - delSum[k]) +
+					costs.transpose(r1[k-1], r1[i-1]) +
+					(insSum[j-1] - insSum[l])
+				d = minF(d, swapDist)
+			}
+
+			dist[(i*cols)+j] = d
 		}
-		seenRunes[r1[i]] = i
+		seenRunes[r1[i-1]] = i
 	}
 
-	return matrix[len(r1)-1][len(r2)-1]
+	return dist
 }
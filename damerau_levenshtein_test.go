@@ -41,3 +41,32 @@ func TestDamerauLevenshtein(t *testing.T) {
 		}
 	}
 }
+
+var damlevweightedtests = []struct {
+	s1    string
+	s2    string
+	costs CostModel
+	dist  float64
+}{
+	{"ab", "ba", CostModel{Insert: 1, Delete: 1, Substitute: 1, Transpose: 1.5}, 1.5},
+	{"ca", "abc", CostModel{Insert: 1, Delete: 1, Substitute: 1, Transpose: 1.5}, 2.5},
+	{"ca", "abc", CostModel{Insert: 0.5, Delete: 1, Substitute: 1, Transpose: 1}, 1.5},
+	{"B0B", "BOB", ocrCosts, 0.25},
+}
+
+// Weighted Damerau-Levenshtein
+func TestDamerauLevenshteinWeighted(t *testing.T) {
+	for _, tt := range damlevtests {
+		dist := DamerauLevenshteinWeighted(tt.s1, tt.s2, UnitCosts())
+		if dist != float64(tt.dist) {
+			t.Errorf("DamerauLevenshteinWeighted('%s', '%s', UnitCosts()) = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range damlevweightedtests {
+		dist := DamerauLevenshteinWeighted(tt.s1, tt.s2, tt.costs)
+		if dist != tt.dist {
+			t.Errorf("DamerauLevenshteinWeighted('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+}
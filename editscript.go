@@ -0,0 +1,243 @@
+package matchr
+
+// EditOp is a single kind of step in an edit script.
+type EditOp int
+
+const (
+	// OpMatch keeps a rune that is the same in both strings.
+	OpMatch EditOp = iota
+	// OpInsert adds a rune from the second string.
+	OpInsert
+	// OpDelete removes a rune from the first string.
+	OpDelete
+	// OpSubstitute replaces a rune in the first string with one from the
+	// second.
+	OpSubstitute
+	// OpTranspose swaps two runes of the first string.
+	OpTranspose
+)
+
+func (op EditOp) String() string {
+	switch op {
+	case OpMatch:
+		return "match"
+	case OpInsert:
+		return "insert"
+	case OpDelete:
+		return "delete"
+	case OpSubstitute:
+		return "substitute"
+	case OpTranspose:
+		return "transpose"
+	default:
+		return "unknown"
+	}
+}
+
+// Edit is one step in the transformation of one string (s1) into another
+// (s2). Pos1 and Pos2 are the rune offsets in s1 and s2 that the step
+// applies to. For an OpInsert, Pos1 is the offset in s1 the new rune goes
+// before, and for an OpDelete, Pos2 is the offset in s2 the removed rune would
+// have occupied.
+//
+// An OpTranspose swaps s1[Pos1] and s1[Swap1] to give s2[Pos2] and s2[Swap2].
+// Usually the two runes are adjacent, but Damerau-Levenshtein also allows
+// runes between them to be deleted or inserted; those edits immediately
+// follow the OpTranspose in the script. Swap1 and Swap2 are unused for every
+// other operation.
+type Edit struct {
+	Op    EditOp
+	Pos1  int
+	Pos2  int
+	Swap1 int
+	Swap2 int
+}
+
+// LevenshteinEditScript computes the Levenshtein distance between two
+// strings along with one of the cheapest sequences of edits that transforms
+// s1 into s2. The script lists every rune of both strings in order, so the
+// number of edits that are not an OpMatch is the distance.
+func LevenshteinEditScript(s1 string, s2 string) (distance int, script []Edit) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	costs := UnitCosts()
+	dist := levenshteinMatrix(r1, r2, &costs)
+
+	return editTraceback(r1, r2, dist, &costs, nil)
+}
+
+// OSAEditScript computes the Optimal String Alignment distance between two
+// strings along with one of the cheapest sequences of edits that transforms
+// s1 into s2. The script lists every rune of both strings in order, so the
+// number of edits that are not an OpMatch is the distance.
+func OSAEditScript(s1 string, s2 string) (distance int, script []Edit) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	costs := UnitCosts()
+	dist := osaMatrix(r1, r2, &costs)
+
+	return editTraceback(r1, r2, dist, &costs, osaSwap)
+}
+
+// DamerauLevenshteinEditScript computes the Damerau-Levenshtein distance
+// between two strings along with one of the cheapest sequences of edits that
+// transforms s1 into s2. The script lists every rune of both strings in
+// order, so the number of edits that are not an OpMatch is the distance.
+func DamerauLevenshteinEditScript(s1 string, s2 string) (distance int, script []Edit) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	costs := UnitCosts()
+	dist := damerauLevenshteinMatrix(r1, r2, &costs)
+
+	return editTraceback(r1, r2, dist, &costs, damerauLevenshteinSwap)
+}
+
+// swapFunc reports the table cell (k, l) a transposition ending at cell
+// (i, j) starts from, along with what the transposition costs, or ok ==
+// false if no transposition ends there.
+type swapFunc func(r1 []rune, r2 []rune, i int, j int, costs *CostModel) (k int, l int, cost float64, ok bool)
+
+// a transposition of the adjacent runes ending at r1[i-1] and r2[j-1]
+func osaSwap(r1 []rune, r2 []rune, i int, j int, costs *CostModel) (k int, l int, cost float64, ok bool) {
+	if i > 1 && j > 1 && r1[i-1] == r2[j-2] &&
+		r1[i-2] == r2[j-1] && r1[i-1] != r1[i-2] {
+		return i - 1, j - 1, costs.transpose(r1[i-2], r1[i-1]), true
+	}
+	return
+}
+
+// a transposition of r1[k-1] and r1[i-1], with everything between them
+// deleted from r1 and everything between r2[l-1] and r2[j-1] inserted
+func damerauLevenshteinSwap(r1 []rune, r2 []rune, i int, j int, costs *CostModel) (k int, l int, cost float64, ok bool) {
+	for k = i - 1; k > 0 && r1[k-1] != r2[j-1]; k-- {
+	}
+	for l = j - 1; l > 0 && r2[l-1] != r1[i-1]; l-- {
+	}
+	if k == 0 || l == 0 {
+		return 0, 0, 0, false
+	}
+
+	cost = costs.transpose(r1[k-1], r1[i-1])
+	for n := k; n < i-1; n++ {
+		cost += costs.delete(r1[n])
+	}
+	for n := l; n < j-1; n++ {
+		cost += costs.insert(r2[n])
+	}
+
+	return k, l, cost, true
+}
+
+// editTraceback walks a filled-in distance table from the bottom right
+// corner back to the top left, recording one of the cheapest paths.
+func editTraceback(r1 []rune, r2 []rune, dist []float64, costs *CostModel,
+	swap swapFunc) (distance int, script []Edit) {
+	cols := len(r2) + 1
+
+	i := len(r1)
+	j := len(r2)
+	for i > 0 || j > 0 {
+		d := dist[(i*cols)+j]
+
+		if i > 0 && j > 0 &&
+			d == dist[((i-1)*cols)+(j-1)]+costs.substitute(r1[i-1], r2[j-1]) {
+			if r1[i-1] == r2[j-1] {
+				script = append(script, Edit{Op: OpMatch, Pos1: i - 1, Pos2: j - 1})
+			} else {
+				script = append(script, Edit{Op: OpSubstitute, Pos1: i - 1, Pos2: j - 1})
+				distance++
+			}
+			i--
+			j--
+			continue
+		}
+
+		if swap != nil && i > 0 && j > 0 {
+			if k, l, cost, ok := swap(r1, r2, i, j, costs); ok &&
+				d == dist[((k-1)*cols)+(l-1)]+cost {
+				// appended back to front, like the rest of the script
+				for n := j - 2; n >= l; n-- {
+					script = append(script, Edit{Op: OpInsert, Pos1: i - 1, Pos2: n})
+				}
+				for n := i - 2; n >= k; n-- {
+					script = append(script, Edit{Op: OpDelete, Pos1: n, Pos2: l})
+				}
+				script = append(script, Edit{Op: OpTranspose, Pos1: k - 1, Pos2: l - 1,
+					Swap1: i - 1, Swap2: j - 1})
+				distance += 1 + (i - 1 - k) + (j - 1 - l)
+				i = k - 1
+				j = l - 1
+				continue
+			}
+		}
+
+		if i > 0 && d == dist[((i-1)*cols)+j]+costs.delete(r1[i-1]) {
+			script = append(script, Edit{Op: OpDelete, Pos1: i - 1, Pos2: j})
+			i--
+		} else {
+			script = append(script, Edit{Op: OpInsert, Pos1: i, Pos2: j - 1})
+			j--
+		}
+		distance++
+	}
+
+	// the script was built from the end, so put it in order
+	for a, b := 0, len(script)-1; a < b; a, b = a+1, b-1 {
+		script[a], script[b] = script[b], script[a]
+	}
+
+	return
+}
+
+// Align renders an edit script of s1 into s2 as two strings of equal rune
+// length, one above the other, so that the runes each edit applies to line
+// up. Runes that are inserted or deleted are padded with gap in the other
+// string. Transposed runes are shown in their original order, so they
+// appear crossed over.
+func Align(s1 string, s2 string, script []Edit, gap rune) (a1 string, a2 string) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	out1 := make([]rune, 0, len(script)+1)
+	out2 := make([]rune, 0, len(script)+1)
+
+	emit := func(e Edit) {
+		switch e.Op {
+		case OpInsert:
+			out1 = append(out1, gap)
+			out2 = append(out2, r2[e.Pos2])
+		case OpDelete:
+			out1 = append(out1, r1[e.Pos1])
+			out2 = append(out2, gap)
+		default:
+			out1 = append(out1, r1[e.Pos1])
+			out2 = append(out2, r2[e.Pos2])
+		}
+	}
+
+	for n := 0; n < len(script); n++ {
+		e := script[n]
+		emit(e)
+
+		if e.Op == OpTranspose {
+			// anything inserted or deleted between the swapped runes
+			for n+1 < len(script) &&
+				((script[n+1].Op == OpDelete && script[n+1].Pos1 < e.Swap1) ||
+					(script[n+1].Op == OpInsert && script[n+1].Pos2 < e.Swap2)) {
+				n++
+				emit(script[n])
+			}
+			out1 = append(out1, r1[e.Swap1])
+			out2 = append(out2, r2[e.Swap2])
+		}
+	}
+
+	return string(out1), string(out2)
+}
@@ -0,0 +1,80 @@
+package matchr
+
+import (
+	"strings"
+	"testing"
+)
+
+var editscripttests = []struct {
+	s1   string
+	s2   string
+	f    func(string, string) (int, []Edit)
+	name string
+	dist int
+	a1   string
+	a2   string
+}{
+	{"car", "cars", LevenshteinEditScript, "LevenshteinEditScript", 1, "car-", "cars"},
+	{"library", "librari", LevenshteinEditScript, "LevenshteinEditScript", 1, "library", "librari"},
+	{"library", "librayr", LevenshteinEditScript, "LevenshteinEditScript", 2, "library", "librayr"},
+	{"library", "librayr", OSAEditScript, "OSAEditScript", 1, "library", "librayr"},
+	{"ab", "ba", OSAEditScript, "OSAEditScript", 1, "ab", "ba"},
+	{"ca", "abc", OSAEditScript, "OSAEditScript", 3, "-ca", "abc"},
+	{"ca", "abc", DamerauLevenshteinEditScript, "DamerauLevenshteinEditScript", 2, "c-a", "abc"},
+	{"Schüßler", "Schüler", DamerauLevenshteinEditScript, "DamerauLevenshteinEditScript", 1, "Schüßler", "Schü-ler"},
+	{"", "", LevenshteinEditScript, "LevenshteinEditScript", 0, "", ""},
+}
+
+func TestEditScript(t *testing.T) {
+	for _, tt := range editscripttests {
+		dist, script := tt.f(tt.s1, tt.s2)
+		if dist != tt.dist {
+			t.Errorf("%s('%s', '%s') distance = %v, want %v", tt.name, tt.s1, tt.s2, dist, tt.dist)
+		}
+
+		a1, a2 := Align(tt.s1, tt.s2, script, '-')
+		if a1 != tt.a1 || a2 != tt.a2 {
+			t.Errorf("Align of %s('%s', '%s') = ('%s', '%s'), want ('%s', '%s')",
+				tt.name, tt.s1, tt.s2, a1, a2, tt.a1, tt.a2)
+		}
+	}
+}
+
+// every script must account for both strings and agree with the distance
+func checkEditScript(t *testing.T, name string, s1 string, s2 string, want int, dist int, script []Edit) {
+	if dist != want {
+		t.Errorf("%s('%s', '%s') distance = %v, want %v", name, s1, s2, dist, want)
+	}
+
+	edits := 0
+	for _, e := range script {
+		if e.Op != OpMatch {
+			edits++
+		}
+	}
+	if edits != dist {
+		t.Errorf("%s('%s', '%s') has %v edits, want %v", name, s1, s2, edits, dist)
+	}
+
+	a1, a2 := Align(s1, s2, script, '-')
+	if strings.ReplaceAll(a1, "-", "") != s1 || strings.ReplaceAll(a2, "-", "") != s2 {
+		t.Errorf("Align of %s('%s', '%s') = ('%s', '%s')", name, s1, s2, a1, a2)
+	}
+}
+
+func TestEditScriptConsistency(t *testing.T) {
+	for _, tt := range levtests {
+		dist, script := LevenshteinEditScript(tt.s1, tt.s2)
+		checkEditScript(t, "LevenshteinEditScript", tt.s1, tt.s2, tt.dist, dist, script)
+	}
+
+	for _, tt := range osatests {
+		dist, script := OSAEditScript(tt.s1, tt.s2)
+		checkEditScript(t, "OSAEditScript", tt.s1, tt.s2, tt.dist, dist, script)
+	}
+
+	for _, tt := range damlevtests {
+		dist, script := DamerauLevenshteinEditScript(tt.s1, tt.s2)
+		checkEditScript(t, "DamerauLevenshteinEditScript", tt.s1, tt.s2, tt.dist, dist, script)
+	}
+}
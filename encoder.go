@@ -0,0 +1,84 @@
+package matchr
+
+// EncoderFunc computes the phonetic keys of a string.
+type EncoderFunc func(s string) []string
+
+// Encoder is a named phonetic encoding. Encode returns every key the
+// algorithm produces for a string, so that single-key algorithms such as
+// Soundex and multi-key ones such as DoubleMetaphone can be used the same
+// way. Keys are distinct and never empty; a string with no encoding yields
+// no keys. Every phonetic algorithm in the package is available as an
+// Encoder in the Encoders registry.
+type Encoder interface {
+	// Name is the key the encoder is registered under in Encoders.
+	Name() string
+
+	// Encode computes the phonetic keys of s.
+	Encode(s string) []string
+}
+
+type encoder struct {
+	name string
+	f    EncoderFunc
+}
+
+// NewEncoder wraps a phonetic encoding function as an Encoder.
+func NewEncoder(name string, f EncoderFunc) Encoder {
+	return &encoder{name: name, f: f}
+}
+
+func (e *encoder) Name() string {
+	return e.name
+}
+
+func (e *encoder) Encode(s string) []string {
+	return e.f(s)
+}
+
+// Encoders holds every phonetic algorithm in the package keyed by name, so
+// that they can be chosen from configuration.
+var Encoders map[string]Encoder
+
+func init() {
+	Encoders = make(map[string]Encoder)
+
+	register := func(e Encoder) {
+		Encoders[e.Name()] = e
+	}
+
+	register(NewEncoder("soundex", singleKey(Soundex)))
+	register(NewEncoder("phonex", singleKey(Phonex)))
+	register(NewEncoder("nysiis", singleKey(NYSIIS)))
+	register(NewEncoder("doublemetaphone", func(s string) []string {
+		return distinctKeys(DoubleMetaphone(s))
+	}))
+}
+
+// adapt an encoding that only produces one key
+func singleKey(f func(string) string) EncoderFunc {
+	return func(s string) []string {
+		return distinctKeys(f(s))
+	}
+}
+
+// drop empty and repeated keys, keeping the rest in order
+func distinctKeys(keys ...string) []string {
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+
+		seen := false
+		for _, r := range result {
+			if r == k {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			result = append(result, k)
+		}
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package matchr
+
+import (
+	"reflect"
+	"testing"
+)
+
+var encodertests = []struct {
+	name string
+	s    string
+	keys []string
+}{
+	{"soundex", "Robert", []string{"R163"}},
+	{"soundex", "", []string{}},
+	{"phonex", "Peter", []string{"B360"}},
+	{"nysiis", "Macintosh", []string{"MCANT"}},
+	{"doublemetaphone", "Smith", []string{"SM0", "XMT"}},
+	{"doublemetaphone", "Thompson", []string{"TMPS"}},
+}
+
+func TestEncoders(t *testing.T) {
+	for _, tt := range encodertests {
+		e, ok := Encoders[tt.name]
+		if !ok {
+			t.Errorf("Encoders['%s'] is not registered", tt.name)
+			continue
+		}
+
+		keys := e.Encode(tt.s)
+		if !reflect.DeepEqual(keys, tt.keys) {
+			t.Errorf("Encoders['%s'].Encode('%s') = %v, want %v", tt.name, tt.s, keys, tt.keys)
+		}
+	}
+
+	for name, e := range Encoders {
+		if e.Name() != name {
+			t.Errorf("Encoders['%s'].Name() = %v", name, e.Name())
+		}
+	}
+}
@@ -1,7 +1,87 @@
 package matchr
 
+import "unicode"
+
+// JaroWinklerOptions holds the constants of the Winkler modification to the
+// Jaro distance, which raises the score of strings sharing a common prefix.
+// Start from DefaultJaroWinklerOptions and adjust from there; keep
+// PrefixScale*MaxPrefix no larger than 1 so the result stays between 0
+// and 1.
+type JaroWinklerOptions struct {
+	// PrefixScale is how much each rune of common prefix raises the score,
+	// as a fraction of the distance left to 1.
+	PrefixScale float64
+
+	// MaxPrefix caps the length of the common prefix that counts.
+	MaxPrefix int
+
+	// BoostThreshold is the Jaro distance a pair must exceed before the
+	// prefix raises its score at all.
+	BoostThreshold float64
+
+	// LongTolerance further raises the score of long strings with many
+	// common characters, as the longTolerance argument of JaroWinkler does.
+	LongTolerance bool
+
+	// PrefixDigits lets digits count toward the common prefix. By default
+	// the prefix stops at the first digit, and strings starting with a
+	// digit get no long string tolerance adjustment, since a shared leading
+	// number (a house number, say) says little about the rest.
+	PrefixDigits bool
+
+	// Similar, when set, gives partial credit for runes that did not match
+	// anything but are similar to an unmatched rune of the other string,
+	// as in Winkler's strcmp95. See DefaultSimilarRunes.
+	Similar SimilarRunes
+}
+
+// SimilarRunes holds pairs of runes that are easily confused, e.g. through
+// OCR errors or transliteration, along with how much of a common character
+// each pair counts as, between 0 and 1. The order of a pair does not
+// matter.
+type SimilarRunes map[[2]rune]float64
+
+// the credit for a pair of runes, in either order
+func (s SimilarRunes) credit(a rune, b rune) float64 {
+	if c, ok := s[[2]rune{a, b}]; ok {
+		return c
+	}
+	return s[[2]rune{b, a}]
+}
+
+// DefaultSimilarRunes returns the table of similar characters from
+// Winkler's strcmp95, in both upper and lower case, with each pair counting
+// as 0.3 of a common character.
+func DefaultSimilarRunes() SimilarRunes {
+	pairs := []string{
+		"AE", "AI", "AO", "AU", "BV", "EI", "EO", "EU", "IO", "IU", "OU",
+		"IY", "EY", "CG", "EF", "WU", "WV", "XK", "SZ", "XS", "QC", "UV",
+		"MN", "LI", "QO", "PR", "IJ", "2Z", "5S", "8B", "1I", "1L", "0O",
+		"0Q", "CK", "GJ", "E ", "Y ", "S ",
+	}
+
+	similar := make(SimilarRunes)
+	for _, p := range pairs {
+		r := []rune(p)
+		similar[[2]rune{r[0], r[1]}] = 0.3
+		similar[[2]rune{unicode.ToLower(r[0]), unicode.ToLower(r[1])}] = 0.3
+	}
+	return similar
+}
+
+// DefaultJaroWinklerOptions returns the options JaroWinkler uses, without
+// the long string tolerance adjustment.
+func DefaultJaroWinklerOptions() JaroWinklerOptions {
+	return JaroWinklerOptions{
+		PrefixScale:    0.1,
+		MaxPrefix:      4,
+		BoostThreshold: 0.7,
+		LongTolerance:  false,
+	}
+}
+
 func jaroWinklerBase(s1 string, s2 string,
-	longTolerance bool, winklerize bool) (distance float64) {
+	opts *JaroWinklerOptions, winklerize bool) (distance float64) {
 
 	// index by code point, not byte
 	r1 := []rune(s1)
@@ -82,31 +162,50 @@ func jaroWinklerBase(s1 string, s2 string,
 	transCount /= 2
 
 	// adjust for similarities in nonmatched characters
-	distance = float64(commonChars)/float64(r1Length) +
-		float64(commonChars)/float64(r2Length) +
+	similarChars := float64(commonChars)
+	if opts.Similar != nil && min(r1Length, r2Length) > commonChars {
+		for i := range r1 {
+			if r1Flag[i] {
+				continue
+			}
+			for j := range r2 {
+				if r2Flag[j] {
+					continue
+				}
+				if c := opts.Similar.credit(r1[i], r2[j]); c > 0 {
+					similarChars += c
+					r2Flag[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	distance = similarChars/float64(r1Length) +
+		similarChars/float64(r2Length) +
 		(float64(commonChars-transCount))/float64(commonChars)
 	distance /= 3.0
 
 	// give more weight to already-similar strings
-	if winklerize && distance > 0.7 {
+	if winklerize && distance > opts.BoostThreshold {
 
-		// the first 4 characters in common
-		if minLength >= 4 {
-			j = 4
+		// the first few characters in common
+		if minLength >= opts.MaxPrefix {
+			j = opts.MaxPrefix
 		} else {
 			j = minLength
 		}
 
-		for i = 0; i < j && len(r1) > i && len(r2) > i && r1[i] == r2[i] && nan(r1[i]); i++ {
+		for i = 0; i < j && len(r1) > i && len(r2) > i && r1[i] == r2[i] && (opts.PrefixDigits || nan(r1[i])); i++ {
 		}
 
 		if i > 0 {
-			distance += float64(i) * 0.1 * (1.0 - distance)
+			distance += float64(i) * opts.PrefixScale * (1.0 - distance)
 		}
 
-		if longTolerance && (minLength > 4) && (commonChars > i+1) &&
+		if opts.LongTolerance && (minLength > 4) && (commonChars > i+1) &&
 			(2*commonChars >= minLength+i) {
-			if nan(r1[0]) {
+			if opts.PrefixDigits || nan(r1[0]) {
 				distance += (1.0 - distance) * (float64(commonChars-i-1) /
 					(float64(r1Length) + float64(r2Length) - float64(i*2) + 2))
 			}
@@ -124,12 +223,28 @@ func jaroWinklerBase(s1 string, s2 string,
 // See http://en.wikipedia.org/wiki/Jaro%E2%80%93Winkler_distance for a
 // full description.
 func Jaro(r1 string, r2 string) (distance float64) {
-	return jaroWinklerBase(r1, r2, false, false)
+	opts := DefaultJaroWinklerOptions()
+	return jaroWinklerBase(r1, r2, &opts, false)
 }
 
 // JaroWinkler computes the Jaro-Winkler edit distance between two strings.
 // This is a modification of the Jaro algorithm that gives additional weight
 // to prefix matches.
 func JaroWinkler(r1 string, r2 string, longTolerance bool) (distance float64) {
-	return jaroWinklerBase(r1, r2, longTolerance, true)
+	opts := DefaultJaroWinklerOptions()
+	opts.LongTolerance = longTolerance
+	return jaroWinklerBase(r1, r2, &opts, true)
+}
+
+// JaroWinklerWithOptions computes the Jaro-Winkler edit distance between two
+// strings like JaroWinkler, but with the prefix weighting given by opts.
+// Short codes and long names often call for different prefix weighting.
+func JaroWinklerWithOptions(r1 string, r2 string, opts JaroWinklerOptions) (distance float64) {
+	return jaroWinklerBase(r1, r2, &opts, true)
+}
+
+// JaroWinkler without the long string tolerance adjustment, for use as the
+// default inner similarity of the token-based comparators
+func jaroWinklerSim(s1 string, s2 string) float64 {
+	return JaroWinkler(s1, s2, false)
 }
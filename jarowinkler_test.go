@@ -49,3 +49,83 @@ func TestJaroWinkler(t *testing.T) {
 		}
 	}
 }
+
+var jarowoptstests = []struct {
+	s1   string
+	s2   string
+	opts JaroWinklerOptions
+	dist float64
+}{
+	{"martha", "marhta", DefaultJaroWinklerOptions(), 0.9611111111111111},
+	{"dixon", "dicksonx", DefaultJaroWinklerOptions(), 0.8133333333333332},
+	// no prefix weighting at all is plain Jaro
+	{"martha", "marhta", JaroWinklerOptions{PrefixScale: 0, MaxPrefix: 4, BoostThreshold: 0.7}, 0.9444444444444445},
+	// a heavier weight on a shorter prefix
+	{"martha", "marhta", JaroWinklerOptions{PrefixScale: 0.2, MaxPrefix: 2, BoostThreshold: 0.7}, 0.9444444444444445 + 0.4*(1-0.9444444444444445)},
+	// a threshold the pair does not reach
+	{"dixon", "dicksonx", JaroWinklerOptions{PrefixScale: 0.1, MaxPrefix: 4, BoostThreshold: 0.8}, 0.7666666666666666},
+	// a longer prefix
+	{"abcdefgh", "abcdefgx", JaroWinklerOptions{PrefixScale: 0.1, MaxPrefix: 6, BoostThreshold: 0.7}, Jaro("abcdefgh", "abcdefgx") + 0.6*(1-Jaro("abcdefgh", "abcdefgx"))},
+}
+
+// Jaro-Winkler distance with options
+func TestJaroWinklerWithOptions(t *testing.T) {
+	for _, tt := range jarowoptstests {
+		dist := JaroWinklerWithOptions(tt.s1, tt.s2, tt.opts)
+		if round(dist, 12) != round(tt.dist, 12) {
+			t.Errorf("JaroWinklerWithOptions('%s', '%s', %+v) = %v, want %v", tt.s1, tt.s2, tt.opts, dist, tt.dist)
+		}
+	}
+
+	// the defaults must agree with JaroWinkler, with and without tolerance
+	for _, tt := range jarowtests {
+		for _, long := range []bool{false, true} {
+			opts := DefaultJaroWinklerOptions()
+			opts.LongTolerance = long
+			if dist, want := JaroWinklerWithOptions(tt.s1, tt.s2, opts), JaroWinkler(tt.s1, tt.s2, long); dist != want {
+				t.Errorf("JaroWinklerWithOptions('%s', '%s', %+v) = %v, want %v", tt.s1, tt.s2, opts, dist, want)
+			}
+		}
+	}
+}
+
+// Jaro-Winkler distance with digits in the prefix and similar characters
+func TestJaroWinklerDigitsAndSimilar(t *testing.T) {
+	opts := DefaultJaroWinklerOptions()
+	jaro := Jaro("123 Main", "123 Mian")
+
+	// by default the prefix stops at the first digit
+	if dist := JaroWinklerWithOptions("123 Main", "123 Mian", opts); dist != jaro {
+		t.Errorf("JaroWinklerWithOptions('123 Main', '123 Mian') = %v, want %v", dist, jaro)
+	}
+
+	opts.PrefixDigits = true
+	want := jaro + 4*0.1*(1-jaro)
+	if dist := JaroWinklerWithOptions("123 Main", "123 Mian", opts); round(dist, 12) != round(want, 12) {
+		t.Errorf("JaroWinklerWithOptions('123 Main', '123 Mian') with PrefixDigits = %v, want %v", dist, want)
+	}
+
+	// O and 0 never match, but are similar under the default table
+	opts = DefaultJaroWinklerOptions()
+	opts.BoostThreshold = 1
+	plain := JaroWinklerWithOptions("B0B", "BOB", opts)
+	if round(plain, 12) != round((2.0/3+2.0/3+1)/3, 12) {
+		t.Errorf("JaroWinklerWithOptions('B0B', 'BOB') = %v, want %v", plain, (2.0/3+2.0/3+1)/3)
+	}
+
+	opts.Similar = DefaultSimilarRunes()
+	want = (2.3/3 + 2.3/3 + 1) / 3
+	if dist := JaroWinklerWithOptions("B0B", "BOB", opts); round(dist, 12) != round(want, 12) {
+		t.Errorf("JaroWinklerWithOptions('B0B', 'BOB') with Similar = %v, want %v", dist, want)
+	}
+	if dist := JaroWinklerWithOptions("b0b", "bob", opts); round(dist, 12) != round(want, 12) {
+		t.Errorf("JaroWinklerWithOptions('b0b', 'bob') with Similar = %v, want %v", dist, want)
+	}
+
+	// a custom table, such as transliteration pairs
+	opts.Similar = SimilarRunes{{'ß', 's'}: 0.9}
+	want = (7.9/8 + 7.9/8 + 1) / 3
+	if dist := JaroWinklerWithOptions("Schüßler", "Schüsler", opts); round(dist, 12) != round(want, 12) {
+		t.Errorf("JaroWinklerWithOptions('Schüßler', 'Schüsler') with Similar = %v, want %v", dist, want)
+	}
+}
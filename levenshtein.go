@@ -49,33 +49,162 @@ func Levenshtein_old(s1, s2 string) (distance int) {
 	return
 }
 
-// Found at: https://en.wikibooks.org/wiki/Algorithm_Implementation/Strings/Levenshtein_distance#Go
-// Also see: https://xlinux.nist.gov/dads/HTML/Levenshtein.html
-// This version uses dynamic programming with time complexity of O(mn) where m and n are lengths of a and b,
-// and the space complexity is n + 1 of integers plus some constant  space(i.e. O(n)).
+// Levenshtein computes the Levenshtein distance between two strings. It
+// uses the bit-parallel algorithm of Myers, which handles up to 64 runes of
+// the shorter string at once, so comparing names and other short strings
+// takes time proportional to the length of the longer one alone.
 func Levenshtein(a, b string) int {
-	f := make([]int, utf8.RuneCountInString(b)+1)
+	la := utf8.RuneCountInString(a)
+	lb := utf8.RuneCountInString(b)
 
+	// the shorter string is the pattern
+	if la > lb {
+		a, b = b, a
+		la, lb = lb, la
+	}
+
+	if la == 0 {
+		return lb
+	}
+
+	if la <= 64 {
+		return myers64(a, b, la, nil)
+	}
+
+	peq := make(map[rune]int)
+	words := make([]uint64, (myersRunes(a, peq)+2)*((la+63)/64))
+	return myersBlocked(a, b, la, peq, words)
+}
+
+// LevenshteinWithin computes the Levenshtein distance between two strings,
+// but only as long as it stays within the maximum distance k. Only the
+// diagonal band of width 2k+1 of the distance table is filled in, and the
+// computation is abandoned as soon as every cell in the current row exceeds
+// k. The returned value - distance - is the same as Levenshtein when ok is
+// true. When ok is false the strings are more than k edits apart and
+// distance is k+1. A negative k never matches.
+func LevenshteinWithin(a, b string, k int) (distance int, ok bool) {
+	if k < 0 {
+		return 0, false
+	}
+
+	// index by code point, not byte
+	r1 := []rune(a)
+	r2 := []rune(b)
+
+	// no distance exceeds the longer length, so a larger k is no tighter,
+	// and clamping it keeps k+1 from overflowing
+	k = min(k, maxI(len(r1), len(r2)))
+
+	// any cell at or above this value is outside the threshold
+	inf := k + 1
+
+	// the length difference alone is a lower bound on the distance
+	if absI(len(r1)-len(r2)) > k {
+		return inf, false
+	}
+
+	f := make([]int, len(r2)+1)
 	for j := range f {
-		f[j] = j
+		f[j] = min(j, inf)
 	}
 
-	for _, ca := range a {
-		j := 1
-		fj1 := f[0] // fj1 is the value of f[j - 1] in last iteration
-		f[0]++
-		for _, cb := range b {
+	for i := 1; i <= len(r1); i++ {
+		lo := maxI(1, i-k)
+		hi := min(len(r2), i+k)
+
+		// fj1 is the value of f[j - 1] in the previous row (the diagonal)
+		fj1 := f[lo-1]
+		if lo == 1 {
+			f[0] = min(i, inf)
+		} else {
+			f[lo-1] = inf
+		}
+		rowMin := f[lo-1]
+
+		ca := r1[i-1]
+		for j := lo; j <= hi; j++ {
 			mn := min(f[j]+1, f[j-1]+1) // delete & insert
-			if cb != ca {
+			if r2[j-1] != ca {
 				mn = min(mn, fj1+1) // change
 			} else {
 				mn = min(mn, fj1) // matched
 			}
+			mn = min(mn, inf)
+
+			fj1, f[j] = f[j], mn
+			rowMin = min(rowMin, mn)
+		}
+
+		// nothing in this row is within k, so nothing below it can be
+		if rowMin > k {
+			return inf, false
+		}
+	}
+
+	distance = f[len(f)-1]
+	if distance > k {
+		return inf, false
+	}
+
+	return distance, true
+}
+
+// LevenshteinWeighted computes the Levenshtein distance between two strings
+// using the given cost model instead of charging one distance point for
+// every step. Transposition costs are ignored, since Levenshtein does not
+// allow them. With UnitCosts() it returns the same value as Levenshtein.
+func LevenshteinWeighted(s1, s2 string, costs CostModel) (distance float64) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	prev := make([]float64, len(r2)+1)
+	curr := make([]float64, len(r2)+1)
+
+	for j := 1; j <= len(r2); j++ {
+		prev[j] = prev[j-1] + costs.insert(r2[j-1])
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = prev[0] + costs.delete(r1[i-1])
+		for j := 1; j <= len(r2); j++ {
+			curr[j] = minF(
+				minF(prev[j]+costs.delete(r1[i-1]), curr[j-1]+costs.insert(r2[j-1])),
+				prev[j-1]+costs.substitute(r1[i-1], r2[j-1]))
+		}
+		prev, curr = curr, prev
+	}
+
+	distance = prev[len(r2)]
+
+	return
+}
+
+// levenshteinMatrix fills in the full (len(r1)+1) x (len(r2)+1) Levenshtein
+// distance table, stored row by row, under the given cost model.
+func levenshteinMatrix(r1 []rune, r2 []rune, costs *CostModel) []float64 {
+	rows := len(r1) + 1
+	cols := len(r2) + 1
+
+	dist := make([]float64, rows*cols)
+
+	for i := 1; i < rows; i++ {
+		dist[i*cols] = dist[(i-1)*cols] + costs.delete(r1[i-1])
+	}
+
+	for j := 1; j < cols; j++ {
+		dist[j] = dist[j-1] + costs.insert(r2[j-1])
+	}
 
-			fj1, f[j] = f[j], mn // save f[j] to fj1(j is about to increase), update f[j] to mn
-			j++
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			dist[(i*cols)+j] = minF(
+				minF(dist[((i-1)*cols)+j]+costs.delete(r1[i-1]),
+					dist[(i*cols)+(j-1)]+costs.insert(r2[j-1])),
+				dist[((i-1)*cols)+(j-1)]+costs.substitute(r1[i-1], r2[j-1]))
 		}
 	}
 
-	return f[len(f)-1]
+	return dist
 }
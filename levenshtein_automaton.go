@@ -0,0 +1,272 @@
+package matchr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LevenshteinAutomaton is a deterministic finite automaton that accepts
+// exactly the strings within k edits of a word. Feeding it a candidate one
+// rune at a time tells as soon as the candidate can no longer match, which
+// makes it possible to search a sorted word list or a Trie without
+// computing a distance for every term.
+//
+// With transpositions, an edit may also swap two adjacent runes, so the
+// automaton accepts the strings within OSA distance k of the word;
+// otherwise it accepts the strings within Levenshtein distance k.
+//
+// Each state of the automaton stands for the last row of the distance table
+// of the word against the runes fed so far, with every value above k
+// treated alike. Since only whether a rune equals each rune of the word
+// matters, all runes that do not appear in the word share their
+// transitions. The whole automaton is built up front, after which it is
+// immutable and safe for concurrent use.
+type LevenshteinAutomaton struct {
+	word           []rune
+	k              int
+	transpositions bool
+
+	// the transition class of each rune of the word; every other rune
+	// belongs to the class numbered len(classRunes)
+	classes    map[rune]int
+	classRunes []rune
+
+	// per state: the distance table row, the transposition row (see
+	// step), and the outgoing transitions, with -1 for the dead state
+	rows    [][]int
+	pending [][]int
+	trans   [][]int
+}
+
+// MaxAutomatonDistance is the largest number of edits a LevenshteinAutomaton
+// can be built for. Every state is built up front, and their number grows
+// exponentially with k and with the length of the word: at k = 3, a 30-rune
+// word takes around two thousand states and a tenth of a second to build,
+// while at k = 5 it already takes tens of thousands and seconds. The limit
+// keeps construction from effectively hanging or running out of memory.
+const MaxAutomatonDistance = 3
+
+// NewLevenshteinAutomaton builds the automaton accepting the strings within
+// k edits of word. It returns an error if k is more than
+// MaxAutomatonDistance. A negative k never matches.
+func NewLevenshteinAutomaton(word string, k int, transpositions bool) (*LevenshteinAutomaton, error) {
+	if k > MaxAutomatonDistance {
+		return nil, fmt.Errorf("Levenshtein automaton distance %d is more than %d.", k, MaxAutomatonDistance)
+	}
+
+	a := &LevenshteinAutomaton{
+		word:           []rune(word),
+		k:              k,
+		transpositions: transpositions,
+		classes:        make(map[rune]int),
+	}
+
+	for _, r := range a.word {
+		if _, ok := a.classes[r]; !ok {
+			a.classes[r] = len(a.classRunes)
+			a.classRunes = append(a.classRunes, r)
+		}
+	}
+
+	// the distance of every prefix of the word from the empty string
+	row := make([]int, len(a.word)+1)
+	for j := range row {
+		row[j] = min(j, k+1)
+	}
+	pending := make([]int, len(a.word)+1)
+	for j := range pending {
+		pending[j] = k + 1
+	}
+
+	if k < 0 {
+		return a, nil
+	}
+
+	// discover every reachable state breadth first
+	index := map[string]int{automatonKey(row, pending): 0}
+	a.rows = append(a.rows, row)
+	a.pending = append(a.pending, pending)
+
+	for s := 0; s < len(a.rows); s++ {
+		trans := make([]int, len(a.classRunes)+1)
+		for c := range trans {
+			nextRow, nextPending, alive := a.step(a.rows[s], a.pending[s], c)
+			if !alive {
+				trans[c] = -1
+				continue
+			}
+
+			key := automatonKey(nextRow, nextPending)
+			next, ok := index[key]
+			if !ok {
+				next = len(a.rows)
+				index[key] = next
+				a.rows = append(a.rows, nextRow)
+				a.pending = append(a.pending, nextPending)
+			}
+			trans[c] = next
+		}
+		a.trans = append(a.trans, trans)
+	}
+
+	return a, nil
+}
+
+// step computes the distance table row after feeding a rune of the given
+// class. pending[j] holds the cost of reaching column j by transposing the
+// rune just fed with the next one, which is only possible when the next one
+// is word[j-2]; it is infinite (k+1) everywhere else.
+func (a *LevenshteinAutomaton) step(row []int, pending []int, class int) (nextRow []int, nextPending []int, alive bool) {
+	inf := a.k + 1
+	n := len(a.word)
+
+	nextRow = make([]int, n+1)
+	nextRow[0] = min(row[0]+1, inf)
+	best := nextRow[0]
+
+	for j := 1; j <= n; j++ {
+		cost := 1
+		if a.classes[a.word[j-1]] == class {
+			cost = 0
+		}
+
+		d := min(min(row[j]+1, nextRow[j-1]+1), row[j-1]+cost)
+		if a.transpositions && j > 1 && a.classes[a.word[j-2]] == class {
+			d = min(d, pending[j])
+		}
+
+		nextRow[j] = min(d, inf)
+		best = min(best, nextRow[j])
+	}
+
+	nextPending = make([]int, n+1)
+	for j := range nextPending {
+		nextPending[j] = inf
+		if a.transpositions && j > 1 && a.word[j-1] != a.word[j-2] &&
+			a.classes[a.word[j-1]] == class {
+			nextPending[j] = min(row[j-2]+1, inf)
+		}
+	}
+
+	return nextRow, nextPending, best <= a.k
+}
+
+// a state's identity, for telling which states have been seen before
+func automatonKey(row []int, pending []int) string {
+	var sb strings.Builder
+	for _, v := range row {
+		sb.WriteRune(rune(v))
+	}
+	for _, v := range pending {
+		sb.WriteRune(rune(v))
+	}
+	return sb.String()
+}
+
+// Start returns the state of the automaton before any runes are fed to it,
+// or -1 if nothing can match, which is the case for a negative k.
+func (a *LevenshteinAutomaton) Start() int {
+	if len(a.rows) == 0 {
+		return -1
+	}
+	return 0
+}
+
+// Step feeds a rune to the automaton in the given state and returns the
+// state it moves to. The dead state, -1, means that no string starting with
+// the runes fed so far can match; stepping from it stays there.
+func (a *LevenshteinAutomaton) Step(state int, r rune) int {
+	if state < 0 {
+		return -1
+	}
+
+	class, ok := a.classes[r]
+	if !ok {
+		class = len(a.classRunes)
+	}
+	return a.trans[state][class]
+}
+
+// IsMatch tells whether the runes fed so far are within k edits of the
+// word.
+func (a *LevenshteinAutomaton) IsMatch(state int) bool {
+	return state >= 0 && a.rows[state][len(a.word)] <= a.k
+}
+
+// Distance returns how many edits the runes fed so far are from the word,
+// with ok == false if that is more than k.
+func (a *LevenshteinAutomaton) Distance(state int) (distance int, ok bool) {
+	if !a.IsMatch(state) {
+		return a.k + 1, false
+	}
+	return a.rows[state][len(a.word)], true
+}
+
+// Match tells whether s is within k edits of the word, and if so, how many.
+func (a *LevenshteinAutomaton) Match(s string) (distance int, ok bool) {
+	state := a.Start()
+	for _, r := range s {
+		state = a.Step(state, r)
+		if state < 0 {
+			break
+		}
+	}
+	return a.Distance(state)
+}
+
+// FilterSorted returns the words in the list, which must be sorted in
+// ascending order, that are within k edits of the automaton's word. Once a
+// prefix can no longer match, every following word that shares it is
+// skipped over with a binary search rather than examined.
+func (a *LevenshteinAutomaton) FilterSorted(words []string) (matches []string) {
+	start := a.Start()
+	if start < 0 {
+		return
+	}
+
+	// states[i] is the state after the first i runes of prev
+	states := []int{start}
+	var prev []rune
+
+	for i := 0; i < len(words); {
+		w := []rune(words[i])
+
+		// resume from the prefix shared with the previous word
+		l := 0
+		for l < len(prev) && l < len(w) && l < len(states)-1 && prev[l] == w[l] {
+			l++
+		}
+		states = states[:l+1]
+
+		dead := false
+		for _, r := range w[l:] {
+			s := a.Step(states[len(states)-1], r)
+			if s < 0 {
+				dead = true
+				break
+			}
+			states = append(states, s)
+		}
+
+		if !dead {
+			if a.IsMatch(states[len(states)-1]) {
+				matches = append(matches, words[i])
+			}
+			prev = w
+			i++
+			continue
+		}
+
+		// skip every word that starts with the prefix that failed
+		prefix := string(w[:len(states)])
+		rest := words[i+1:]
+		skip := sort.Search(len(rest), func(x int) bool {
+			return !strings.HasPrefix(rest[x], prefix)
+		})
+		prev = w[:len(states)-1]
+		i += 1 + skip
+	}
+
+	return
+}
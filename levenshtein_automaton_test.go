@@ -0,0 +1,127 @@
+package matchr
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var automatontests = []struct {
+	word           string
+	k              int
+	transpositions bool
+	s              string
+	dist           int
+	ok             bool
+}{
+	{"car", 1, false, "cars", 1, true},
+	{"car", 0, false, "cars", 1, false},
+	{"library", 1, false, "librayr", 2, false},
+	{"library", 1, true, "librayr", 1, true},
+	{"ab", 1, true, "ba", 1, true},
+	{"ca", 2, true, "abc", 3, false},
+	{"Schüßler", 1, false, "Schüler", 1, true},
+	{"Schüßler", 2, false, "Schüßler", 0, true},
+	{"", 2, false, "ab", 2, true},
+	{"", 2, false, "abc", 3, false},
+	{"car", -1, false, "car", 0, false},
+	{"car", MaxAutomatonDistance, false, "xyz", 3, true},
+}
+
+func TestLevenshteinAutomaton(t *testing.T) {
+	for _, tt := range automatontests {
+		a, err := NewLevenshteinAutomaton(tt.word, tt.k, tt.transpositions)
+		if err != nil {
+			t.Fatalf("NewLevenshteinAutomaton('%s', %d, %v) error = %v", tt.word, tt.k, tt.transpositions, err)
+		}
+
+		dist, ok := a.Match(tt.s)
+		if ok != tt.ok || (ok && dist != tt.dist) {
+			t.Errorf("NewLevenshteinAutomaton('%s', %d, %v).Match('%s') = (%v, %v), want (%v, %v)",
+				tt.word, tt.k, tt.transpositions, tt.s, dist, ok, tt.dist, tt.ok)
+		}
+	}
+
+	for _, k := range []int{MaxAutomatonDistance + 1, math.MaxInt} {
+		if _, err := NewLevenshteinAutomaton("car", k, false); err == nil {
+			t.Errorf("NewLevenshteinAutomaton('car', %d, false) did not return an error", k)
+		}
+	}
+}
+
+// The automaton must accept exactly what the distance functions allow.
+func TestLevenshteinAutomatonExhaustive(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	random := func() string {
+		b := make([]rune, r.Intn(8))
+		for i := range b {
+			b[i] = []rune("abcü")[r.Intn(4)]
+		}
+		return string(b)
+	}
+
+	for n := 0; n < 200; n++ {
+		word := random()
+		for k := 0; k <= 3; k++ {
+			lev, _ := NewLevenshteinAutomaton(word, k, false)
+			osa, _ := NewLevenshteinAutomaton(word, k, true)
+
+			for m := 0; m < 20; m++ {
+				s := random()
+
+				want := Levenshtein(word, s)
+				dist, ok := lev.Match(s)
+				if ok != (want <= k) || (ok && dist != want) {
+					t.Fatalf("Levenshtein automaton ('%s', %d).Match('%s') = (%v, %v), want %v",
+						word, k, s, dist, ok, want)
+				}
+
+				want = OSA(word, s)
+				dist, ok = osa.Match(s)
+				if ok != (want <= k) || (ok && dist != want) {
+					t.Fatalf("OSA automaton ('%s', %d).Match('%s') = (%v, %v), want %v",
+						word, k, s, dist, ok, want)
+				}
+			}
+		}
+	}
+}
+
+var automatonwords = []string{
+	"apple", "applesauce", "apply", "ample", "banana", "band", "bandana",
+	"can", "candy", "cane", "cat", "cats", "dog", "Schüler", "Schüßler",
+}
+
+func TestLevenshteinAutomatonFilterSorted(t *testing.T) {
+	words := append([]string(nil), automatonwords...)
+	sort.Strings(words)
+
+	for _, word := range []string{"aple", "cat", "bandanna", "Schüsler", "zzz"} {
+		for k := 0; k <= 2; k++ {
+			a, _ := NewLevenshteinAutomaton(word, k, false)
+
+			var want []string
+			for _, w := range words {
+				if Levenshtein(word, w) <= k {
+					want = append(want, w)
+				}
+			}
+
+			if got := a.FilterSorted(words); !reflect.DeepEqual(got, want) {
+				t.Errorf("FilterSorted for ('%s', %d) = %v, want %v", word, k, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkLevenshteinAutomaton(b *testing.B) {
+	words := strings.Fields(strings.Repeat("apple applesauce apply ample banana band ", 10))
+	sort.Strings(words)
+	for n := 0; n < b.N; n++ {
+		a, _ := NewLevenshteinAutomaton("aplesauce", 2, true)
+		_ = a.FilterSorted(words)
+	}
+}
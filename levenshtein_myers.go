@@ -0,0 +1,169 @@
+package matchr
+
+// Bit-parallel Levenshtein distance after Myers, "A fast bit-vector
+// algorithm for approximate string matching based on dynamic programming"
+// (1999), in the form Hyyrö gives for the edit distance of two whole strings
+// in "Explaining and extending the bit-parallel approximate string matching
+// algorithm of Myers" (2001).
+//
+// Instead of filling in the distance table one cell at a time, each column
+// is kept as two bit vectors recording where the distance goes up (pv) or
+// down (mv) from one row to the next, and a whole column is computed from
+// the previous one with a handful of word operations. The pattern (the
+// shorter string) runs down the rows, so a pattern of up to 64 runes fits in
+// a single word; longer ones are split into blocks of 64 rows with the
+// horizontal difference carried from one block into the next.
+
+// myers64 computes the Levenshtein distance between pattern, which must hold
+// between 1 and 64 runes (m of them), and text. Runes beyond Latin-1 have
+// their match vectors kept in high, which may be nil, and must otherwise be
+// empty.
+func myers64(pattern string, text string, m int, high map[rune]uint64) int {
+	// the rows at which each rune appears in the pattern
+	var low [256]uint64
+
+	i := 0
+	for _, c := range pattern {
+		if c < 256 {
+			low[c] |= 1 << uint(i)
+		} else {
+			if high == nil {
+				high = make(map[rune]uint64)
+			}
+			high[c] |= 1 << uint(i)
+		}
+		i++
+	}
+
+	last := uint64(1) << uint(m-1)
+
+	// every row starts one more than the row above it
+	pv := ^uint64(0)
+	mv := uint64(0)
+	score := m
+
+	for _, c := range text {
+		var eq uint64
+		if c < 256 {
+			eq = low[c]
+		} else {
+			eq = high[c]
+		}
+
+		xv := eq | mv
+		xh := (((eq & pv) + pv) ^ pv) | eq
+		ph := mv | ^(xh | pv)
+		mh := pv & xh
+
+		if ph&last != 0 {
+			score++
+		} else if mh&last != 0 {
+			score--
+		}
+
+		// the top row goes up by one with every column
+		ph = (ph << 1) | 1
+		mh <<= 1
+		pv = mh | ^(xv | ph)
+		mv = ph & xv
+	}
+
+	return score
+}
+
+// myersBlocked computes the Levenshtein distance between pattern, which
+// holds m runes with m > 0, and text, using as many 64-row blocks as it
+// takes to cover the pattern. peq holds the number of each distinct rune of
+// the pattern, as set up by myersRunes, and words the bit vectors, one per
+// block for each of pv, mv and the distinct runes, in that order; it must be
+// all zero to begin with.
+func myersBlocked(pattern string, text string, m int, peq map[rune]int, words []uint64) int {
+	blocks := (m + 63) / 64
+
+	pv := words[0:blocks]
+	mv := words[blocks : 2*blocks]
+	for b := range pv {
+		pv[b] = ^uint64(0)
+	}
+
+	// the rows at which each rune appears in each block of the pattern
+	i := 0
+	for _, c := range pattern {
+		masks := words[(2+peq[c])*blocks:]
+		masks[i/64] |= 1 << uint(i%64)
+		i++
+	}
+
+	// the row the score is kept for, within the last block
+	last := uint64(1) << uint((m-1)%64)
+	score := m
+
+	for _, c := range text {
+		var masks []uint64
+		if x, ok := peq[c]; ok {
+			masks = words[(2+x)*blocks : (3+x)*blocks]
+		}
+
+		// the top row goes up by one with every column
+		hin := 1
+		for b := 0; b < blocks; b++ {
+			var eq uint64
+			if masks != nil {
+				eq = masks[b]
+			}
+
+			if b == blocks-1 {
+				hin = advanceBlock(&pv[b], &mv[b], eq, hin, last)
+			} else {
+				hin = advanceBlock(&pv[b], &mv[b], eq, hin, 1<<63)
+			}
+		}
+		score += hin
+	}
+
+	return score
+}
+
+// myersRunes numbers each distinct rune of pattern in peq, which must be
+// empty, and returns how many there are.
+func myersRunes(pattern string, peq map[rune]int) (n int) {
+	for _, c := range pattern {
+		if _, ok := peq[c]; !ok {
+			peq[c] = n
+			n++
+		}
+	}
+	return
+}
+
+// advanceBlock moves one 64-row block of a column forward to the next
+// column. hin is the horizontal difference (-1, 0 or +1) coming into the top
+// row of the block, and the returned value is the horizontal difference at
+// the row selected by out.
+func advanceBlock(pv *uint64, mv *uint64, eq uint64, hin int, out uint64) (hout int) {
+	xv := eq | *mv
+	if hin < 0 {
+		eq |= 1
+	}
+	xh := (((eq & *pv) + *pv) ^ *pv) | eq
+	ph := *mv | ^(xh | *pv)
+	mh := *pv & xh
+
+	if ph&out != 0 {
+		hout = 1
+	} else if mh&out != 0 {
+		hout = -1
+	}
+
+	ph <<= 1
+	mh <<= 1
+	if hin < 0 {
+		mh |= 1
+	} else if hin > 0 {
+		ph |= 1
+	}
+	*pv = mh | ^(xv | ph)
+	*mv = ph & xv
+
+	return
+}
@@ -1,6 +1,11 @@
 package matchr
 
-import "testing"
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
 
 var levtests = []struct {
 	s1   string
@@ -42,6 +47,58 @@ func TestLevenshtein(t *testing.T) {
 	}
 }
 
+// a random string of n runes, drawn from a small alphabet that reaches
+// beyond Latin-1 so that both match tables get exercised
+func randomLevString(r *rand.Rand, n int) string {
+	alphabet := []rune("abcüßЖ語")
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteRune(alphabet[r.Intn(len(alphabet))])
+	}
+	return sb.String()
+}
+
+// The bit-parallel implementation must agree with the dynamic programming
+// one, both for patterns that fit in a single word and for blocked ones.
+func TestLevenshteinBitParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	s := NewScorer()
+
+	for n := 0; n < 2000; n++ {
+		s1 := randomLevString(r, r.Intn(200))
+		s2 := randomLevString(r, r.Intn(200))
+
+		want := Levenshtein_old(s1, s2)
+		if dist := Levenshtein(s1, s2); dist != want {
+			t.Fatalf("Levenshtein('%s', '%s') = %v, want %v", s1, s2, dist, want)
+		}
+		if dist := s.Levenshtein(s1, s2); dist != want {
+			t.Fatalf("Scorer.Levenshtein('%s', '%s') = %v, want %v", s1, s2, dist, want)
+		}
+	}
+
+	// the edges of a single word
+	for _, n := range []int{63, 64, 65, 128, 129} {
+		s1 := strings.Repeat("a", n)
+		s2 := strings.Repeat("b", n)
+		if dist := Levenshtein(s1, s2); dist != n {
+			t.Errorf("Levenshtein of %d different runes = %v, want %v", n, dist, n)
+		}
+		if dist := Levenshtein(s1, s1+"a"); dist != 1 {
+			t.Errorf("Levenshtein of %d runes and one more = %v, want 1", n, dist)
+		}
+	}
+}
+
+func BenchmarkLevenshteinLong(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	s1 := randomLevString(r, 150)
+	s2 := randomLevString(r, 150)
+	for n := 0; n < b.N; n++ {
+		_ = Levenshtein(s1, s2)
+	}
+}
+
 func BenchmarkLevenshtein(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for _, tt := range levtests {
@@ -57,3 +114,103 @@ func BenchmarkLevenshtein_DP(b *testing.B) {
 		}
 	}
 }
+
+var levwithintests = []struct {
+	s1   string
+	s2   string
+	k    int
+	dist int
+	ok   bool
+}{
+	{"car", "cars", 1, 1, true},
+	{"car", "cars", 0, 1, false},
+	{"kitten", "sitting", 3, 3, true},
+	{"kitten", "sitting", 2, 3, false},
+	{"", "library", 7, 7, true},
+	{"", "library", 2, 3, false},
+	{"", "", 0, 0, true},
+	{"abcdef", "uvwxyz", 2, 3, false},
+	{"Schüßler", "Schübler", 1, 1, true},
+	{"Schüßler", "Schüßler", 0, 0, true},
+	{"car", "car", -1, 0, false},
+	// no limit at all
+	{"kitten", "sitting", math.MaxInt, 3, true},
+}
+
+// Bounded Levenshtein
+func TestLevenshteinWithin(t *testing.T) {
+	for _, tt := range levwithintests {
+		dist, ok := LevenshteinWithin(tt.s1, tt.s2, tt.k)
+		if dist != tt.dist || ok != tt.ok {
+			t.Errorf("LevenshteinWithin('%s', '%s', %d) = (%v, %v), want (%v, %v)",
+				tt.s1, tt.s2, tt.k, dist, ok, tt.dist, tt.ok)
+		}
+	}
+
+	// with a generous threshold it must agree with Levenshtein
+	for _, tt := range levtests {
+		for k := 0; k <= 10; k++ {
+			want := Levenshtein(tt.s1, tt.s2)
+			dist, ok := LevenshteinWithin(tt.s1, tt.s2, k)
+			if ok != (want <= k) || (ok && dist != want) {
+				t.Errorf("LevenshteinWithin('%s', '%s', %d) = (%v, %v), want distance %v",
+					tt.s1, tt.s2, k, dist, ok, want)
+			}
+		}
+	}
+}
+
+func BenchmarkLevenshteinWithin(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		for _, tt := range levtests {
+			_, _ = LevenshteinWithin(tt.s1, tt.s2, 2)
+		}
+	}
+}
+
+// OCR-style model in which confusing 0/O and 1/l is cheap
+var ocrCosts = CostModel{
+	Insert:    1,
+	Delete:    1,
+	Transpose: 1,
+	SubstituteFunc: func(a, b rune) float64 {
+		switch {
+		case a == '0' && b == 'O', a == 'O' && b == '0':
+			return 0.25
+		case a == '1' && b == 'l', a == 'l' && b == '1':
+			return 0.25
+		}
+		return 1
+	},
+}
+
+var levweightedtests = []struct {
+	s1    string
+	s2    string
+	costs CostModel
+	dist  float64
+}{
+	{"B0B", "BOB", ocrCosts, 0.25},
+	{"he11o", "hello", ocrCosts, 0.5},
+	{"B0B", "BAB", ocrCosts, 1},
+	{"car", "cars", CostModel{Insert: 2, Delete: 1, Substitute: 1}, 2},
+	{"cars", "car", CostModel{Insert: 2, Delete: 1, Substitute: 1}, 1},
+	{"car", "cat", CostModel{Insert: 1, Delete: 1, Substitute: 3}, 2},
+}
+
+// Weighted Levenshtein
+func TestLevenshteinWeighted(t *testing.T) {
+	for _, tt := range levtests {
+		dist := LevenshteinWeighted(tt.s1, tt.s2, UnitCosts())
+		if dist != float64(tt.dist) {
+			t.Errorf("LevenshteinWeighted('%s', '%s', UnitCosts()) = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range levweightedtests {
+		dist := LevenshteinWeighted(tt.s1, tt.s2, tt.costs)
+		if dist != tt.dist {
+			t.Errorf("LevenshteinWeighted('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+}
@@ -1,18 +1,31 @@
 package matchr
 
+import "sort"
+
 // LongestCommonSubsequence computes the longest substring
 // between two strings. The returned value is the length
 // of the substring, which contains letters from both
 // strings, while maintaining the order of the letters.
 func LongestCommonSubsequence(s1, s2 string) int {
+	// index by code point, not byte
 	r1 := []rune(s1)
 	r2 := []rune(s2)
-	table := make([][]int, len(s1)+1)
+	rows := make([]int, 2*(len(r2)+1))
 
-	// Construct 2D table
-	for i := range table {
-		table[i] = make([]int, len(s2)+1)
-	}
+	return longestCommonSubsequence(r1, r2, rows)
+}
+
+// longestCommonSubsequence computes the length of the longest common
+// subsequence of two rune slices. The length table is filled in from the
+// bottom up and only the row below the current one is ever needed, so just
+// those two rows are kept in rows. It must hold at least 2*(len(r2)+1)
+// values, all of them zero.
+func longestCommonSubsequence(r1 []rune, r2 []rune, rows []int) int {
+	cols := len(r2) + 1
+
+	// the row below and the one being filled in
+	next := rows[0:cols]
+	curr := rows[cols : 2*cols]
 
 	var i int
 	var j int
@@ -20,11 +33,117 @@ func LongestCommonSubsequence(s1, s2 string) int {
 	for i = len(r1) - 1; i >= 0; i-- {
 		for j = len(r2) - 1; j >= 0; j-- {
 			if r1[i] == r2[j] {
-				table[i][j] = 1 + table[i+1][j+1]
+				curr[j] = 1 + next[j+1]
+			} else {
+				curr[j] = maxI(next[j], curr[j+1])
+			}
+		}
+		next, curr = curr, next
+	}
+	return next[0]
+}
+
+// LongestCommonSubsequenceString returns a longest common subsequence of
+// two strings itself rather than its length. When there are several, it
+// returns just one of them; AllLongestCommonSubsequences returns them all.
+func LongestCommonSubsequenceString(s1 string, s2 string) string {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+	cols := len(r2) + 1
+	table := longestCommonSubsequenceTable(r1, r2)
+
+	subsequence := make([]rune, 0, table[0])
+	for i, j := 0, 0; i < len(r1) && j < len(r2); {
+		if r1[i] == r2[j] {
+			subsequence = append(subsequence, r1[i])
+			i++
+			j++
+		} else if table[((i+1)*cols)+j] >= table[(i*cols)+j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return string(subsequence)
+}
+
+// AllLongestCommonSubsequences returns every distinct longest common
+// subsequence of two strings, in ascending order. Two empty strings, or two
+// with no runes in common, have only the empty subsequence in common.
+//
+// The number of longest common subsequences can grow exponentially with the
+// length of the strings, so this is only suitable for short ones.
+func AllLongestCommonSubsequences(s1 string, s2 string) []string {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+	cols := len(r2) + 1
+	table := longestCommonSubsequenceTable(r1, r2)
+
+	// the distinct longest common subsequences of r1[i:] and r2[j:], by cell
+	memo := make(map[int][]string)
+
+	var collect func(i int, j int) []string
+	collect = func(i int, j int) []string {
+		c := (i * cols) + j
+		if table[c] == 0 {
+			return []string{""}
+		}
+		if subsequences, ok := memo[c]; ok {
+			return subsequences
+		}
+
+		var subsequences []string
+		if r1[i] == r2[j] {
+			for _, s := range collect(i+1, j+1) {
+				subsequences = append(subsequences, string(r1[i])+s)
+			}
+		} else {
+			seen := make(map[string]bool)
+			if table[c+cols] == table[c] {
+				for _, s := range collect(i+1, j) {
+					seen[s] = true
+					subsequences = append(subsequences, s)
+				}
+			}
+			if table[c+1] == table[c] {
+				for _, s := range collect(i, j+1) {
+					if !seen[s] {
+						subsequences = append(subsequences, s)
+					}
+				}
+			}
+		}
+
+		memo[c] = subsequences
+		return subsequences
+	}
+
+	subsequences := append([]string(nil), collect(0, 0)...)
+	sort.Strings(subsequences)
+	return subsequences
+}
+
+// longestCommonSubsequenceTable fills in the whole length table that
+// longestCommonSubsequence keeps two rows of: the value for cell (i, j),
+// at index i*(len(r2)+1)+j, is the length of the longest common subsequence
+// of r1[i:] and r2[j:].
+func longestCommonSubsequenceTable(r1 []rune, r2 []rune) []int {
+	cols := len(r2) + 1
+	table := make([]int, (len(r1)+1)*cols)
+
+	for i := len(r1) - 1; i >= 0; i-- {
+		for j := len(r2) - 1; j >= 0; j-- {
+			c := (i * cols) + j
+			if r1[i] == r2[j] {
+				table[c] = 1 + table[c+cols+1]
 			} else {
-				table[i][j] = maxI(table[i+1][j], table[i][j+1])
+				table[c] = maxI(table[c+cols], table[c+1])
 			}
 		}
 	}
-	return table[0][0]
+
+	return table
 }
@@ -1,6 +1,9 @@
 package matchr
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 var lcstests = []struct {
 	s1     string
@@ -31,3 +34,44 @@ func TestLongestCommonSubsequence(t *testing.T) {
 		}
 	}
 }
+
+var lcsstringtests = []struct {
+	s1  string
+	s2  string
+	lcs string
+	all []string
+}{
+	{"coins", "cons", "cons", []string{"cons"}},
+	{"ebay", "bay", "bay", []string{"bay"}},
+	{"abc", "acb", "ac", []string{"ab", "ac"}},
+	{"abcbdab", "bdcaba", "bdab", []string{"bcab", "bcba", "bdab"}},
+	{"Schüßler", "Schüler", "Schüler", []string{"Schüler"}},
+	{"abc", "xyz", "", []string{""}},
+	{"", "hello", "", []string{""}},
+	{"", "", "", []string{""}},
+}
+
+func TestLongestCommonSubsequenceString(t *testing.T) {
+	for _, tt := range lcsstringtests {
+		lcs := LongestCommonSubsequenceString(tt.s1, tt.s2)
+		if lcs != tt.lcs {
+			t.Errorf("LongestCommonSubsequenceString('%s', '%s') = '%s', want '%s'", tt.s1, tt.s2, lcs, tt.lcs)
+		}
+	}
+
+	for _, tt := range lcstests {
+		lcs := LongestCommonSubsequenceString(tt.s1, tt.s2)
+		if len([]rune(lcs)) != tt.length {
+			t.Errorf("LongestCommonSubsequenceString('%s', '%s') = '%s', want length %v", tt.s1, tt.s2, lcs, tt.length)
+		}
+	}
+}
+
+func TestAllLongestCommonSubsequences(t *testing.T) {
+	for _, tt := range lcsstringtests {
+		all := AllLongestCommonSubsequences(tt.s1, tt.s2)
+		if !reflect.DeepEqual(all, tt.all) {
+			t.Errorf("AllLongestCommonSubsequences('%s', '%s') = %q, want %q", tt.s1, tt.s2, all, tt.all)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package matchr
+
+// LongestCommonSubstring finds the longest run of runes that appears
+// unbroken in both strings, along with the rune offsets it starts at in
+// each. When there are several, it returns the one that ends earliest in s1,
+// and of those the one that ends earliest in s2. If the strings have no
+// runes in common, the substring is empty and both offsets are 0.
+//
+// Unlike LongestCommonSubsequence, the shared runes must be contiguous in
+// both strings, so "Acme Widgets" and "Acme Wdgets" have "Acme W" in common
+// rather than "Acme Wdgets".
+func LongestCommonSubstring(s1 string, s2 string) (substring string, start1 int, start2 int) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	// the length of the longest common suffix of r1[:i] and r2[:j], kept
+	// for the row above and the current one
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+
+	var length, end1, end2 int
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			if r1[i-1] == r2[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = 0
+			}
+
+			if curr[j] > length {
+				length = curr[j]
+				end1, end2 = i, j
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	if length == 0 {
+		return
+	}
+
+	return string(r1[end1-length : end1]), end1 - length, end2 - length
+}
@@ -0,0 +1,31 @@
+package matchr
+
+import "testing"
+
+var lcsubstringtests = []struct {
+	s1        string
+	s2        string
+	substring string
+	start1    int
+	start2    int
+}{
+	{"Acme Widgets", "Acme Wdgets", "Acme W", 0, 0},
+	{"Invoice from Acme Widgets Inc", "ACME Widgets", " Widgets", 17, 4},
+	{"ebay", "bay", "bay", 1, 0},
+	// the first of several equally long ones
+	{"abxcd", "cdxab", "ab", 0, 3},
+	{"Schüßler", "Herr Schüler", "Schü", 0, 5},
+	{"abc", "xyz", "", 0, 0},
+	{"", "hello", "", 0, 0},
+	{"", "", "", 0, 0},
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	for _, tt := range lcsubstringtests {
+		substring, start1, start2 := LongestCommonSubstring(tt.s1, tt.s2)
+		if substring != tt.substring || start1 != tt.start1 || start2 != tt.start2 {
+			t.Errorf("LongestCommonSubstring('%s', '%s') = ('%s', %v, %v), want ('%s', %v, %v)",
+				tt.s1, tt.s2, substring, start1, start2, tt.substring, tt.start1, tt.start2)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package matchr
 
 import (
 	"bytes"
+	"math"
 	"strings"
 )
 
@@ -608,6 +609,20 @@ func conditionM0(input runestring, index int) bool {
 // More information about this algorithm can be found on Wikipedia at
 // http://en.wikipedia.org/wiki/Metaphone.
 func DoubleMetaphone(s1 string) (string, string) {
+	return DoubleMetaphoneN(s1, 4)
+}
+
+// DoubleMetaphoneN computes the Double-Metaphone value of the input string
+// like DoubleMetaphone, but with keys of up to maxLen characters instead of
+// the traditional four. Longer keys tell apart more names, which keeps the
+// groups of names sharing a key smaller. The first four characters of each
+// key are the same as the ones DoubleMetaphone returns. A maxLen of zero or
+// less does not limit the length of the keys at all.
+func DoubleMetaphoneN(s1 string, maxLen int) (string, string) {
+	if maxLen <= 0 {
+		maxLen = math.MaxInt32
+	}
+
 	// trim, upper space
 	s1 = cleanInput(s1)
 
@@ -623,7 +638,7 @@ func DoubleMetaphone(s1 string) (string, string) {
 		index += 1
 	}
 
-	result := newMetaphoneresult(4, true)
+	result := newMetaphoneresult(maxLen, true)
 
 	for !result.isComplete() && index <= len(input)-1 {
 		c := rune(input.SafeAt(index))
@@ -37,3 +37,72 @@ func TestDoubleMetaphone(t *testing.T) {
 		line, err = r.ReadString('\n')
 	}
 }
+
+var doublemetaphonentests = []struct {
+	s         string
+	maxLen    int
+	metaphone string
+	alternate string
+}{
+	{"Schwarzenegger", 4, "XRSN", "XFRT"},
+	{"Schwarzenegger", 8, "XRSNKR", "XFRTSNKR"},
+	{"Washington", 6, "AXNKTN", "FXNKTN"},
+	{"Christopherson", 6, "KRSTFR", "KRSTFR"},
+	{"Christopherson", 0, "KRSTFRSN", "KRSTFRSN"},
+	{"", 8, "", ""},
+}
+
+func TestDoubleMetaphoneN(t *testing.T) {
+	for _, tt := range doublemetaphonentests {
+		metaphone, alternate := DoubleMetaphoneN(tt.s, tt.maxLen)
+		if metaphone != tt.metaphone || alternate != tt.alternate {
+			t.Errorf("DoubleMetaphoneN('%s', %d) = (%v, %v), want (%v, %v)",
+				tt.s, tt.maxLen, metaphone, alternate, tt.metaphone, tt.alternate)
+		}
+	}
+}
+
+// Longer keys must extend the four character keys of the corpus without
+// changing them.
+func TestDoubleMetaphoneNCorpus(t *testing.T) {
+	// load gzipped corpus
+	f, err := os.Open("double_metaphone_corpus.txt.gz")
+	if err != nil {
+		panic("Error opening file double_metaphone_corpus.txt.gz! Exiting.")
+	}
+	defer f.Close()
+
+	g, err := gzip.NewReader(f)
+	if err != nil {
+		panic("Error with supposedly gzipped file double_metaphone_corpus.txt.gz! Exiting.")
+	}
+
+	r := bufio.NewReader(g)
+
+	line, err := r.ReadString('\n')
+	for err == nil {
+		line = strings.TrimRight(line, "\n")
+		v := strings.Split(line, "|")
+
+		for _, n := range []int{6, 8} {
+			metaphone, alternate := DoubleMetaphoneN(v[0], n)
+			if len(metaphone) > n || len(alternate) > n ||
+				!strings.HasPrefix(metaphone, v[1]) || !strings.HasPrefix(alternate, v[2]) {
+				t.Errorf("DoubleMetaphoneN('%s', %d) = (%v, %v), want extensions of (%v, %v)",
+					v[0], n, metaphone, alternate, v[1], v[2])
+				t.FailNow()
+			}
+
+			// a key only falls short of n characters when the input runs out
+			unlimited, unlimitedAlt := DoubleMetaphoneN(v[0], 0)
+			if !strings.HasPrefix(unlimited, metaphone) || !strings.HasPrefix(unlimitedAlt, alternate) ||
+				(len(metaphone) < n && metaphone != unlimited) {
+				t.Errorf("DoubleMetaphoneN('%s', %d) = (%v, %v), want prefixes of (%v, %v)",
+					v[0], n, metaphone, alternate, unlimited, unlimitedAlt)
+				t.FailNow()
+			}
+		}
+
+		line, err = r.ReadString('\n')
+	}
+}
@@ -0,0 +1,166 @@
+package matchr
+
+import "math"
+
+// MetricKind tells whether a Metric measures distance, where smaller values
+// mean more alike, or similarity, where larger values mean more alike.
+type MetricKind int
+
+const (
+	KindDistance MetricKind = iota
+	KindSimilarity
+)
+
+func (k MetricKind) String() string {
+	switch k {
+	case KindDistance:
+		return "distance"
+	case KindSimilarity:
+		return "similarity"
+	default:
+		return "unknown"
+	}
+}
+
+// MetricFunc compares two strings and scores them with a float64.
+type MetricFunc func(s1 string, s2 string) float64
+
+// Metric is a named string comparator along with what its scores mean.
+// The comparison functions of the package that need nothing but the two
+// strings are available as Metrics in the Metrics registry.
+type Metric interface {
+	// Name is the key the metric is registered under in Metrics.
+	Name() string
+
+	// Compare scores the two strings.
+	Compare(s1 string, s2 string) float64
+
+	// Kind tells whether the score is a distance or a similarity.
+	Kind() MetricKind
+
+	// Range returns the lowest and highest possible scores. Unbounded
+	// scores use math.Inf(1) as the maximum.
+	Range() (lo float64, hi float64)
+}
+
+type metric struct {
+	name string
+	f    MetricFunc
+	kind MetricKind
+	lo   float64
+	hi   float64
+}
+
+// NewMetric wraps a comparison function as a Metric.
+func NewMetric(name string, f MetricFunc, kind MetricKind, lo float64, hi float64) Metric {
+	return &metric{name: name, f: f, kind: kind, lo: lo, hi: hi}
+}
+
+func (m *metric) Name() string {
+	return m.name
+}
+
+func (m *metric) Compare(s1 string, s2 string) float64 {
+	return m.f(s1, s2)
+}
+
+func (m *metric) Kind() MetricKind {
+	return m.kind
+}
+
+func (m *metric) Range() (lo float64, hi float64) {
+	return m.lo, m.hi
+}
+
+// Metrics holds the comparison functions of the package keyed by name, so
+// that they can be chosen from configuration. Functions that take
+// parameters are registered with the defaults described below; those that
+// need more, such as a corpus, a cost model or an inner similarity with no
+// default, are left out. The integer distances are also available as
+// similarities between 0 and 1 normalized ByMaxLength, under their name with
+// a "similarity" suffix. JaroWinkler is registered without the long string
+// tolerance adjustment, the q-gram measures use bigrams without padding,
+// and the Monge-Elkan measures use their default inner similarity.
+var Metrics map[string]Metric
+
+func init() {
+	Metrics = make(map[string]Metric)
+
+	register := func(m Metric) {
+		Metrics[m.Name()] = m
+	}
+
+	inf := math.Inf(1)
+
+	register(NewMetric("levenshtein", func(s1 string, s2 string) float64 {
+		return float64(Levenshtein(s1, s2))
+	}, KindDistance, 0, inf))
+	register(NewMetric("osa", func(s1 string, s2 string) float64 {
+		return float64(OSA(s1, s2))
+	}, KindDistance, 0, inf))
+	register(NewMetric("dameraulevenshtein", func(s1 string, s2 string) float64 {
+		return float64(DamerauLevenshtein(s1, s2))
+	}, KindDistance, 0, inf))
+	register(NewMetric("hamming", hammingMetric, KindDistance, 0, inf))
+	register(NewMetric("longestcommonsubsequence", func(s1 string, s2 string) float64 {
+		return float64(LongestCommonSubsequence(s1, s2))
+	}, KindSimilarity, 0, inf))
+	register(NewMetric("smithwaterman", SmithWaterman, KindSimilarity, 0, inf))
+	register(NewMetric("jaro", Jaro, KindSimilarity, 0, 1))
+	register(NewMetric("jarowinkler", func(s1 string, s2 string) float64 {
+		return JaroWinkler(s1, s2, false)
+	}, KindSimilarity, 0, 1))
+
+	register(NewMetric("levenshteinsimilarity", func(s1 string, s2 string) float64 {
+		return LevenshteinSimilarity(s1, s2, ByMaxLength)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("osasimilarity", func(s1 string, s2 string) float64 {
+		return OSASimilarity(s1, s2, ByMaxLength)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("dameraulevenshteinsimilarity", func(s1 string, s2 string) float64 {
+		return DamerauLevenshteinSimilarity(s1, s2, ByMaxLength)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("hammingsimilarity", func(s1 string, s2 string) float64 {
+		// strings of different lengths have nothing in common
+		similarity, _ := HammingSimilarity(s1, s2)
+		return similarity
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("longestcommonsubsequencesimilarity", func(s1 string, s2 string) float64 {
+		return LongestCommonSubsequenceSimilarity(s1, s2, ByMaxLength)
+	}, KindSimilarity, 0, 1))
+
+	register(NewMetric("qgram", func(s1 string, s2 string) float64 {
+		return float64(QGramDistance(s1, s2, 2, false))
+	}, KindDistance, 0, inf))
+	register(NewMetric("qgramjaccard", func(s1 string, s2 string) float64 {
+		return QGramJaccard(s1, s2, 2, false)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("qgramsorensendice", func(s1 string, s2 string) float64 {
+		return QGramSorensenDice(s1, s2, 2, false)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("qgramoverlap", func(s1 string, s2 string) float64 {
+		return QGramOverlap(s1, s2, 2, false)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("qgramcosine", func(s1 string, s2 string) float64 {
+		return QGramCosine(s1, s2, 2, false)
+	}, KindSimilarity, 0, 1))
+
+	register(NewMetric("mongeelkan", func(s1 string, s2 string) float64 {
+		return MongeElkan(s1, s2, nil)
+	}, KindSimilarity, 0, 1))
+	register(NewMetric("symmetricmongeelkan", func(s1 string, s2 string) float64 {
+		return SymmetricMongeElkan(s1, s2, nil)
+	}, KindSimilarity, 0, 1))
+
+	register(NewMetric("ratcliffobershelp", RatcliffObershelp, KindSimilarity, 0, 1))
+}
+
+// Hamming is only defined for strings of the same length, so any other pair
+// is infinitely far apart.
+func hammingMetric(s1 string, s2 string) float64 {
+	distance, err := Hamming(s1, s2)
+	if err != nil {
+		return math.Inf(1)
+	}
+	return float64(distance)
+}
@@ -0,0 +1,65 @@
+package matchr
+
+import (
+	"math"
+	"testing"
+)
+
+var metrictests = []struct {
+	name  string
+	s1    string
+	s2    string
+	score float64
+}{
+	{"levenshtein", "car", "cars", 1},
+	{"osa", "ca", "abc", 3},
+	{"dameraulevenshtein", "ca", "abc", 2},
+	{"hamming", "car", "cat", 1},
+	{"hamming", "wxyz", "zyx", math.Inf(1)},
+	{"longestcommonsubsequence", "coins", "cons", 4},
+	{"smithwaterman", "car", "cars", 3},
+	{"jaro", "martha", "marhta", 0.9444444444444445},
+	{"jarowinkler", "martha", "marhta", 0.9611111111111111},
+	{"levenshteinsimilarity", "car", "cars", 0.75},
+	{"hammingsimilarity", "wxyz", "zyx", 0},
+	{"longestcommonsubsequencesimilarity", "coins", "cons", 0.8},
+	{"qgram", "night", "nacht", 6},
+	{"qgram", "a", "b", 2},
+	{"qgramjaccard", "night", "nacht", 1.0 / 7.0},
+	{"qgramsorensendice", "night", "nacht", 0.25},
+	{"qgramoverlap", "night", "nacht", 0.25},
+	{"qgramcosine", "night", "nacht", 0.25},
+	{"mongeelkan", "John Smith", "Smith John Paul", 1},
+	{"symmetricmongeelkan", "John Smith", "Smith John", 1},
+	{"ratcliffobershelp", "WIKIMEDIA", "WIKIMANIA", 14.0 / 18.0},
+}
+
+func TestMetrics(t *testing.T) {
+	for _, tt := range metrictests {
+		m, ok := Metrics[tt.name]
+		if !ok {
+			t.Errorf("Metrics['%s'] is not registered", tt.name)
+			continue
+		}
+
+		score := m.Compare(tt.s1, tt.s2)
+		if score != tt.score {
+			t.Errorf("Metrics['%s'].Compare('%s', '%s') = %v, want %v", tt.name, tt.s1, tt.s2, score, tt.score)
+		}
+	}
+
+	for name, m := range Metrics {
+		if m.Name() != name {
+			t.Errorf("Metrics['%s'].Name() = %v", name, m.Name())
+		}
+
+		lo, hi := m.Range()
+		if lo > hi {
+			t.Errorf("Metrics['%s'].Range() = (%v, %v)", name, lo, hi)
+		}
+
+		if m.Kind() == KindSimilarity && hi == 1 && m.Compare("matchr", "matchr") != 1 {
+			t.Errorf("Metrics['%s'] does not score identical strings as 1", name)
+		}
+	}
+}
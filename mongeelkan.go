@@ -0,0 +1,55 @@
+package matchr
+
+// MongeElkan computes the Monge-Elkan similarity of two strings. Both are
+// tokenized, each token of s1 is scored against its best match among the
+// tokens of s2 using the inner similarity sim, and the scores are averaged.
+// This suits person and street names, where tokens may be reordered or
+// misspelled. A nil sim uses JaroWinkler without the long string tolerance
+// adjustment.
+//
+// The result is a float64 between 0 and 1 inclusive when sim is one. It is
+// not symmetric: extra tokens in s2 do not lower the score, but extra tokens
+// in s1 do. See SymmetricMongeElkan for a symmetric variant.
+//
+// See Monge and Elkan, "The field matching problem: Algorithms and
+// applications" (1996).
+func MongeElkan(s1 string, s2 string, sim MetricFunc) float64 {
+	if sim == nil {
+		sim = jaroWinklerSim
+	}
+	return mongeElkan(Tokenize(s1), Tokenize(s2), sim)
+}
+
+// SymmetricMongeElkan computes the mean of the Monge-Elkan similarity of s1
+// to s2 and that of s2 to s1, so that the order of the arguments does not
+// matter.
+func SymmetricMongeElkan(s1 string, s2 string, sim MetricFunc) float64 {
+	if sim == nil {
+		sim = jaroWinklerSim
+	}
+
+	t1 := Tokenize(s1)
+	t2 := Tokenize(s2)
+	return (mongeElkan(t1, t2, sim) + mongeElkan(t2, t1, sim)) / 2
+}
+
+// the mean over the tokens of t1 of their best score against t2
+func mongeElkan(t1 []string, t2 []string, sim MetricFunc) float64 {
+	if len(t1) == 0 || len(t2) == 0 {
+		if len(t1) == len(t2) {
+			return 1
+		}
+		return 0
+	}
+
+	var total float64
+	for _, a := range t1 {
+		var best float64
+		for _, b := range t2 {
+			best = max(best, sim(a, b))
+		}
+		total += best
+	}
+
+	return total / float64(len(t1))
+}
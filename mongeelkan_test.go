@@ -0,0 +1,34 @@
+package matchr
+
+import "testing"
+
+var mongeelkantests = []struct {
+	s1  string
+	s2  string
+	sim MetricFunc
+	me  float64
+	sme float64
+}{
+	{"", "", nil, 1.0, 1.0},
+	{"Smith", "", nil, 0.0, 0.0},
+	{"John Smith", "Smith John", nil, 1.0, 1.0},
+	{"John Smith", "Smith John Paul", nil, 1.0, (1.0 + (2.0+JaroWinkler("Paul", "John", false))/3) / 2},
+	{"Jon Smyth", "John Smith", nil,
+		(JaroWinkler("Jon", "John", false) + JaroWinkler("Smyth", "Smith", false)) / 2,
+		(JaroWinkler("Jon", "John", false) + JaroWinkler("Smyth", "Smith", false)) / 2},
+	{"Main St", "Main Street", levenshteinSimilarityByMax, 0.5 + 2.0/6/2, 0.5 + 2.0/6/2},
+}
+
+func TestMongeElkan(t *testing.T) {
+	for _, tt := range mongeelkantests {
+		me := MongeElkan(tt.s1, tt.s2, tt.sim)
+		if round(me, 12) != round(tt.me, 12) {
+			t.Errorf("MongeElkan('%s', '%s') = %v, want %v", tt.s1, tt.s2, me, tt.me)
+		}
+
+		sme := SymmetricMongeElkan(tt.s1, tt.s2, tt.sim)
+		if round(sme, 12) != round(tt.sme, 12) {
+			t.Errorf("SymmetricMongeElkan('%s', '%s') = %v, want %v", tt.s1, tt.s2, sme, tt.sme)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package matchr
+
+// NeedlemanWunsch computes the best global alignment of the two input
+// strings, i.e. one that runs from end to end of both, under the given
+// scheme with linear gaps: every rune of a gap scores -GapOpen and
+// GapExtend is ignored. It returns the score along with the alignment,
+// which covers the whole of both strings.
+//
+// Scores can be negative, since a global alignment has to account for every
+// rune of both strings, however little they have in common. The whole score
+// table is kept in order to trace the alignment back, so this takes memory
+// proportional to the product of the string lengths.
+func NeedlemanWunsch(s1 string, s2 string, scheme ScoringScheme) Alignment {
+	scheme.GapExtend = scheme.GapOpen
+	return Gotoh(s1, s2, scheme)
+}
+
+// Gotoh computes the best global alignment of the two input strings like
+// NeedlemanWunsch, but with affine gaps as the scheme describes them, so
+// that a single long gap can be penalized less than several short ones,
+// e.g. an address with a whole word missing from the middle.
+func Gotoh(s1 string, s2 string, scheme ScoringScheme) (alignment Alignment) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	t := newAlignmentTables(len(r1), len(r2))
+	cols := t.cols
+
+	// the edges of the table are one gap running from the start
+	for j := 1; j < cols; j++ {
+		t.e[j] = -scheme.GapOpen - float64(j-1)*scheme.GapExtend
+		t.h[j] = t.e[j]
+	}
+	for i := 1; i <= len(r1); i++ {
+		t.f[i*cols] = -scheme.GapOpen - float64(i-1)*scheme.GapExtend
+		t.h[i*cols] = t.f[i*cols]
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j < cols; j++ {
+			t.fill(r1, r2, i, j, &scheme, false)
+		}
+	}
+
+	alignment.Score = t.h[len(t.h)-1]
+	alignment.Script, _, _ = t.traceback(r1, r2, len(r1), len(r2), &scheme, false)
+	alignment.End1 = len(r1)
+	alignment.End2 = len(r2)
+	alignment.Substring1 = s1
+	alignment.Substring2 = s2
+
+	return
+}
@@ -0,0 +1,45 @@
+package matchr
+
+import "testing"
+
+var globalalignmenttests = []struct {
+	s1     string
+	s2     string
+	f      func(string, string, ScoringScheme) Alignment
+	name   string
+	scheme ScoringScheme
+	score  float64
+	a1     string
+	a2     string
+}{
+	{"abcxxxxdef", "abcdef", Gotoh, "Gotoh", ScoringScheme{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, 12 - 3.5, "abcxxxxdef", "abc----def"},
+	{"abcxxxxdef", "abcdef", NeedlemanWunsch, "NeedlemanWunsch", ScoringScheme{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, 12 - 8, "abcxxxxdef", "abc----def"},
+	// unlike a local alignment, the unmatched ends count against the score
+	{"xxabcyy", "abc", NeedlemanWunsch, "NeedlemanWunsch", DefaultScoringScheme(), 1, "xxabcyy", "--abc--"},
+	{"Acme Widgets Inc", "Acme Widgets", Gotoh, "Gotoh", ScoringScheme{Match: 1, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, 8.5, "Acme Widgets Inc", "Acme Widgets----"},
+	{"Schüßler", "Schüler", Gotoh, "Gotoh", DefaultScoringScheme(), 6.5, "Schüßler", "Schü-ler"},
+	{"abc", "abd", NeedlemanWunsch, "NeedlemanWunsch", DefaultScoringScheme(), 1, "abc-", "ab-d"},
+	{"", "abc", Gotoh, "Gotoh", ScoringScheme{Match: 1, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, -3, "---", "abc"},
+	{"abc", "", NeedlemanWunsch, "NeedlemanWunsch", DefaultScoringScheme(), -1.5, "abc", "---"},
+	{"", "", Gotoh, "Gotoh", DefaultScoringScheme(), 0, "", ""},
+}
+
+// Needleman-Wunsch and Gotoh global alignment
+func TestGlobalAlignment(t *testing.T) {
+	for _, tt := range globalalignmenttests {
+		a := tt.f(tt.s1, tt.s2, tt.scheme)
+		if a.Score != tt.score {
+			t.Errorf("%s('%s', '%s') score = %v, want %v", tt.name, tt.s1, tt.s2, a.Score, tt.score)
+		}
+		if a.Start1 != 0 || a.End1 != len([]rune(tt.s1)) || a.Start2 != 0 || a.End2 != len([]rune(tt.s2)) ||
+			a.Substring1 != tt.s1 || a.Substring2 != tt.s2 {
+			t.Errorf("%s('%s', '%s') does not cover both strings: %+v", tt.name, tt.s1, tt.s2, a)
+		}
+
+		a1, a2 := Align(tt.s1, tt.s2, a.Script, '-')
+		if a1 != tt.a1 || a2 != tt.a2 {
+			t.Errorf("Align of %s('%s', '%s') = ('%s', '%s'), want ('%s', '%s')",
+				tt.name, tt.s1, tt.s2, a1, a2, tt.a1, tt.a2)
+		}
+	}
+}
@@ -11,22 +11,30 @@ func OSA(s1 string, s2 string) (distance int) {
 	r1 := []rune(s1)
 	r2 := []rune(s2)
 
-	rows := len(r1) + 1
-	cols := len(r2) + 1
+	rows := make([]int, 3*(len(r2)+1))
 
-	var i, j, d1, d2, d3, d_now, cost int
+	return osa(r1, r2, rows)
+}
+
+// osa computes the OSA distance between two rune slices. Only the last three
+// rows of the distance table are ever needed, and they are kept in rows,
+// which must hold at least 3*(len(r2)+1) values.
+func osa(r1 []rune, r2 []rune, rows []int) (distance int) {
+	cols := len(r2) + 1
 
-	dist := make([]int, rows*cols)
+	// the rows two above, one above, and the one being filled in
+	prev2 := rows[0:cols]
+	prev := rows[cols : 2*cols]
+	curr := rows[2*cols : 3*cols]
 
-	for i = 0; i < rows; i++ {
-		dist[i*cols] = i
-	}
+	var i, j, d1, d2, d3, d_now, cost int
 
 	for j = 0; j < cols; j++ {
-		dist[j] = j
+		prev[j] = j
 	}
 
-	for i = 1; i < rows; i++ {
+	for i = 1; i <= len(r1); i++ {
+		curr[0] = i
 		for j = 1; j < cols; j++ {
 			if r1[i-1] == r2[j-1] {
 				cost = 0
@@ -34,23 +42,78 @@ func OSA(s1 string, s2 string) (distance int) {
 				cost = 1
 			}
 
-			d1 = dist[((i-1)*cols)+j] + 1
-			d2 = dist[(i*cols)+(j-1)] + 1
-			d3 = dist[((i-1)*cols)+(j-1)] + cost
+			d1 = prev[j] + 1
+			d2 = curr[j-1] + 1
+			d3 = prev[j-1] + cost
 
 			d_now = min(d1, min(d2, d3))
 
-			if i > 2 && j > 2 && r1[i-1] == r2[j-2] &&
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] &&
 				r1[i-2] == r2[j-1] {
-				d1 = dist[((i-2)*cols)+(j-2)] + cost
+				d1 = prev2[j-2] + cost
 				d_now = min(d_now, d1)
 			}
 
-			dist[(i*cols)+j] = d_now
+			curr[j] = d_now
 		}
+		prev2, prev, curr = prev, curr, prev2
 	}
 
-	distance = dist[(cols*rows)-1]
+	distance = prev[cols-1]
 
 	return
 }
+
+// OSAWeighted computes the Optimal String Alignment distance between two
+// strings using the given cost model instead of charging one distance point
+// for every step. With UnitCosts() it returns the same value as OSA.
+func OSAWeighted(s1 string, s2 string, costs CostModel) (distance float64) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	dist := osaMatrix(r1, r2, &costs)
+	distance = dist[len(dist)-1]
+
+	return
+}
+
+// osaMatrix fills in the full (len(r1)+1) x (len(r2)+1) OSA distance table,
+// stored row by row, under the given cost model.
+func osaMatrix(r1 []rune, r2 []rune, costs *CostModel) []float64 {
+	rows := len(r1) + 1
+	cols := len(r2) + 1
+
+	var i, j int
+	var d1, d2, d3, d_now float64
+
+	dist := make([]float64, rows*cols)
+
+	for i = 1; i < rows; i++ {
+		dist[i*cols] = dist[(i-1)*cols] + costs.delete(r1[i-1])
+	}
+
+	for j = 1; j < cols; j++ {
+		dist[j] = dist[j-1] + costs.insert(r2[j-1])
+	}
+
+	for i = 1; i < rows; i++ {
+		for j = 1; j < cols; j++ {
+			d1 = dist[((i-1)*cols)+j] + costs.delete(r1[i-1])
+			d2 = dist[(i*cols)+(j-1)] + costs.insert(r2[j-1])
+			d3 = dist[((i-1)*cols)+(j-1)] + costs.substitute(r1[i-1], r2[j-1])
+
+			d_now = minF(d1, minF(d2, d3))
+
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] &&
+				r1[i-2] == r2[j-1] && r1[i-1] != r1[i-2] {
+				d1 = dist[((i-2)*cols)+(j-2)] + costs.transpose(r1[i-2], r1[i-1])
+				d_now = minF(d_now, d1)
+			}
+
+			dist[(i*cols)+j] = d_now
+		}
+	}
+
+	return dist
+}
@@ -28,6 +28,8 @@ var osatests = []struct {
 	{"Schßüler", "Schüßler", 1},
 	{"Schüßler", "Schüler", 1},
 	{"Schüßler", "Schüßlers", 1},
+	// transposition of the leading runes
+	{"ab", "ba", 1},
 	// difference between DL and OSA. This is OSA, so it should be 3.
 	{"ca", "abc", 3},
 }
@@ -41,3 +43,31 @@ func TestOSA(t *testing.T) {
 		}
 	}
 }
+
+var osaweightedtests = []struct {
+	s1    string
+	s2    string
+	costs CostModel
+	dist  float64
+}{
+	{"ab", "ba", CostModel{Insert: 1, Delete: 1, Substitute: 1, Transpose: 0.5}, 0.5},
+	{"library", "librayr", CostModel{Insert: 1, Delete: 1, Substitute: 1, Transpose: 3}, 2},
+	{"B0B", "BOB", ocrCosts, 0.25},
+}
+
+// Weighted OSA
+func TestOSAWeighted(t *testing.T) {
+	for _, tt := range osatests {
+		dist := OSAWeighted(tt.s1, tt.s2, UnitCosts())
+		if dist != float64(tt.dist) {
+			t.Errorf("OSAWeighted('%s', '%s', UnitCosts()) = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range osaweightedtests {
+		dist := OSAWeighted(tt.s1, tt.s2, tt.costs)
+		if dist != tt.dist {
+			t.Errorf("OSAWeighted('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+}
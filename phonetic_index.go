@@ -0,0 +1,118 @@
+package matchr
+
+import "sort"
+
+// PhoneticIndex groups record IDs into blocks by the phonetic keys of a
+// value, so that record linkage only has to compare records that sound
+// alike instead of every pair. A record lands in a block for every key of
+// every encoder, so multi-key encoders such as DoubleMetaphone place it in
+// the blocks of both its primary and its alternate key.
+type PhoneticIndex struct {
+	encoders []Encoder
+
+	// the IDs in each block, keyed by encoder name and phonetic key, and
+	// which IDs each block already holds
+	blocks  map[phoneticKey][]string
+	members map[blockMember]bool
+}
+
+type phoneticKey struct {
+	encoder string
+	key     string
+}
+
+type blockMember struct {
+	block phoneticKey
+	id    string
+}
+
+// CandidatePair is two record IDs that share at least one block.
+type CandidatePair struct {
+	ID1 string
+	ID2 string
+}
+
+// NewPhoneticIndex returns an empty PhoneticIndex that blocks on the keys of
+// the given encoders, e.g. Encoders["doublemetaphone"].
+func NewPhoneticIndex(encoders ...Encoder) *PhoneticIndex {
+	return &PhoneticIndex{
+		encoders: encoders,
+		blocks:   make(map[phoneticKey][]string),
+		members:  make(map[blockMember]bool),
+	}
+}
+
+// Add places the record in the blocks for the value's phonetic keys. A
+// record may be added more than once, e.g. for each of several names.
+func (p *PhoneticIndex) Add(id string, value string) {
+	for _, bk := range p.keys(value) {
+		member := blockMember{block: bk, id: id}
+		if !p.members[member] {
+			p.members[member] = true
+			p.blocks[bk] = append(p.blocks[bk], id)
+		}
+	}
+}
+
+// Block returns the IDs of the records whose value the named encoder gives
+// the key, in the order they were added.
+func (p *PhoneticIndex) Block(encoder string, key string) []string {
+	return p.blocks[phoneticKey{encoder: encoder, key: key}]
+}
+
+// Candidates returns the IDs of every record sharing a block with the
+// query, in ascending order.
+func (p *PhoneticIndex) Candidates(query string) (ids []string) {
+	seen := make(map[string]bool)
+	for _, bk := range p.keys(query) {
+		for _, id := range p.blocks[bk] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	sort.Strings(ids)
+	return
+}
+
+// CandidatePairs returns every pair of distinct records sharing at least
+// one block, each pair once with ID1 < ID2, in ascending order.
+func (p *PhoneticIndex) CandidatePairs() (pairs []CandidatePair) {
+	seen := make(map[CandidatePair]bool)
+	for _, block := range p.blocks {
+		for i := 0; i < len(block); i++ {
+			for j := i + 1; j < len(block); j++ {
+				pair := CandidatePair{ID1: block[i], ID2: block[j]}
+				if pair.ID1 > pair.ID2 {
+					pair.ID1, pair.ID2 = pair.ID2, pair.ID1
+				}
+
+				if !seen[pair] {
+					seen[pair] = true
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].ID1 != pairs[j].ID1 {
+			return pairs[i].ID1 < pairs[j].ID1
+		}
+		return pairs[i].ID2 < pairs[j].ID2
+	})
+
+	return
+}
+
+// the blocks a value belongs in
+func (p *PhoneticIndex) keys(value string) (keys []phoneticKey) {
+	for _, e := range p.encoders {
+		for _, key := range e.Encode(value) {
+			keys = append(keys, phoneticKey{encoder: e.Name(), key: key})
+		}
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package matchr
+
+import (
+	"reflect"
+	"testing"
+)
+
+var phoneticrecords = []struct {
+	id    string
+	value string
+}{
+	{"1", "Smith"},
+	{"2", "Schmidt"},
+	{"3", "Smyth"},
+	{"4", "Robert"},
+	{"5", "Rupert"},
+	{"6", "Jones"},
+}
+
+func TestPhoneticIndex(t *testing.T) {
+	p := NewPhoneticIndex(Encoders["doublemetaphone"])
+	for _, r := range phoneticrecords {
+		p.Add(r.id, r.value)
+	}
+	p.Add("1", "Smith")
+
+	// Smith is SM0 with an alternate of XMT, which Schmidt and Smyth share
+	if block := p.Block("doublemetaphone", "XMT"); !reflect.DeepEqual(block, []string{"1", "2", "3"}) {
+		t.Errorf("PhoneticIndex.Block('doublemetaphone', 'XMT') = %v", block)
+	}
+
+	candidates := p.Candidates("Smithe")
+	if !reflect.DeepEqual(candidates, []string{"1", "2", "3"}) {
+		t.Errorf("PhoneticIndex.Candidates('Smithe') = %v, want [1 2 3]", candidates)
+	}
+
+	if candidates := p.Candidates("Zzyzx"); candidates != nil {
+		t.Errorf("PhoneticIndex.Candidates('Zzyzx') = %v, want nil", candidates)
+	}
+
+	pairs := p.CandidatePairs()
+	want := []CandidatePair{{"1", "2"}, {"1", "3"}, {"2", "3"}, {"4", "5"}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("PhoneticIndex.CandidatePairs() = %v, want %v", pairs, want)
+	}
+}
+
+func TestPhoneticIndexMultipleEncoders(t *testing.T) {
+	p := NewPhoneticIndex(Encoders["soundex"], Encoders["nysiis"])
+	for _, r := range phoneticrecords {
+		p.Add(r.id, r.value)
+	}
+
+	// Robert and Rupert share a Soundex code (R163) but not a NYSIIS one
+	if candidates := p.Candidates("Robert"); !reflect.DeepEqual(candidates, []string{"4", "5"}) {
+		t.Errorf("PhoneticIndex.Candidates('Robert') = %v, want [4 5]", candidates)
+	}
+	if block := p.Block("nysiis", NYSIIS("Robert")); !reflect.DeepEqual(block, []string{"4"}) {
+		t.Errorf("PhoneticIndex.Block('nysiis', '%s') = %v, want [4]", NYSIIS("Robert"), block)
+	}
+}
@@ -0,0 +1,198 @@
+package matchr
+
+import "math"
+
+// the runes used to pad the start and end of a string before cutting it
+// into q-grams; both are control characters, so they do not clash with text
+const (
+	qgramStart = '\u0002'
+	qgramEnd   = '\u0003'
+)
+
+// QGramProfile holds how often each q-gram, a run of q consecutive runes,
+// occurs in a string. Comparing profiles rather than the strings themselves
+// makes the q-gram measures robust to the order of the parts of a string,
+// and a profile can be built once and compared against many others.
+type QGramProfile map[string]int
+
+// NewQGramProfile cuts the string into its overlapping q-grams, indexed by
+// code point, not byte. With padding, q-1 start and end markers are added
+// first so that the runes at either end take part in as many q-grams as
+// the ones in the middle. A q of less than 1 is treated as 1.
+func NewQGramProfile(s string, q int, padded bool) QGramProfile {
+	if q < 1 {
+		q = 1
+	}
+
+	r := []rune(s)
+	if padded && q > 1 {
+		pad := make([]rune, 0, len(r)+2*(q-1))
+		for i := 0; i < q-1; i++ {
+			pad = append(pad, qgramStart)
+		}
+		pad = append(pad, r...)
+		for i := 0; i < q-1; i++ {
+			pad = append(pad, qgramEnd)
+		}
+		r = pad
+	}
+
+	profile := make(QGramProfile)
+	for i := 0; i+q <= len(r); i++ {
+		profile[string(r[i:i+q])]++
+	}
+	return profile
+}
+
+// Distance computes Ukkonen's q-gram distance between two profiles: the
+// number of q-grams occurring in one more often than in the other.
+func (p QGramProfile) Distance(other QGramProfile) (distance int) {
+	for g, n := range p {
+		distance += absI(n - other[g])
+	}
+	for g, n := range other {
+		if _, ok := p[g]; !ok {
+			distance += n
+		}
+	}
+	return
+}
+
+// Jaccard computes the Jaccard index of the sets of distinct q-grams in two
+// profiles: the size of their intersection over the size of their union.
+func (p QGramProfile) Jaccard(other QGramProfile) float64 {
+	common := p.common(other)
+	union := len(p) + len(other) - common
+	if union == 0 {
+		return 1
+	}
+	return float64(common) / float64(union)
+}
+
+// SorensenDice computes the Sørensen-Dice coefficient of the sets of
+// distinct q-grams in two profiles: twice the size of their intersection
+// over the sum of their sizes.
+func (p QGramProfile) SorensenDice(other QGramProfile) float64 {
+	total := len(p) + len(other)
+	if total == 0 {
+		return 1
+	}
+	return float64(2*p.common(other)) / float64(total)
+}
+
+// Overlap computes the overlap coefficient of the sets of distinct q-grams
+// in two profiles: the size of their intersection over the size of the
+// smaller set.
+func (p QGramProfile) Overlap(other QGramProfile) float64 {
+	smaller := min(len(p), len(other))
+	if smaller == 0 {
+		if len(p) == len(other) {
+			return 1
+		}
+		return 0
+	}
+	return float64(p.common(other)) / float64(smaller)
+}
+
+// Cosine computes the cosine similarity of two profiles, taken as vectors
+// of q-gram counts.
+func (p QGramProfile) Cosine(other QGramProfile) float64 {
+	var dot, norm1, norm2 float64
+	for g, n := range p {
+		dot += float64(n * other[g])
+		norm1 += float64(n * n)
+	}
+	for _, n := range other {
+		norm2 += float64(n * n)
+	}
+
+	if norm1 == 0 || norm2 == 0 {
+		if norm1 == norm2 {
+			return 1
+		}
+		return 0
+	}
+	// one square root of the exact integer product keeps identical profiles
+	// at exactly 1
+	return dot / math.Sqrt(norm1*norm2)
+}
+
+// the number of distinct q-grams the profiles have in common
+func (p QGramProfile) common(other QGramProfile) (common int) {
+	if len(other) < len(p) {
+		p, other = other, p
+	}
+	for g := range p {
+		if _, ok := other[g]; ok {
+			common++
+		}
+	}
+	return
+}
+
+// QGramDistance computes Ukkonen's q-gram distance between two strings: the
+// number of q-grams occurring in one more often than in the other. See
+// NewQGramProfile for the meaning of q and padded. When neither string is
+// long enough to have any q-grams, each non-empty one counts as a single
+// q-gram of its own, so that only equal strings are 0 apart.
+func QGramDistance(s1 string, s2 string, q int, padded bool) (distance int) {
+	p1 := NewQGramProfile(s1, q, padded)
+	p2 := NewQGramProfile(s2, q, padded)
+
+	if len(p1) == 0 && len(p2) == 0 {
+		if s1 == s2 {
+			return 0
+		}
+		if s1 != "" {
+			distance++
+		}
+		if s2 != "" {
+			distance++
+		}
+		return
+	}
+	return p1.Distance(p2)
+}
+
+// QGramJaccard computes the Jaccard index of the q-grams of two strings. It
+// represents this with a float64 between 0 and 1 inclusive, with 0
+// indicating the two strings have no q-grams in common and 1 indicating
+// they have all of them in common. Strings too short to have any q-grams
+// only match when they are equal.
+func QGramJaccard(s1 string, s2 string, q int, padded bool) float64 {
+	return qgramSimilarity(s1, s2, q, padded, QGramProfile.Jaccard)
+}
+
+// QGramSorensenDice computes the Sørensen-Dice coefficient of the q-grams of
+// two strings, a float64 between 0 and 1 inclusive like QGramJaccard.
+func QGramSorensenDice(s1 string, s2 string, q int, padded bool) float64 {
+	return qgramSimilarity(s1, s2, q, padded, QGramProfile.SorensenDice)
+}
+
+// QGramOverlap computes the overlap coefficient of the q-grams of two
+// strings, a float64 between 0 and 1 inclusive like QGramJaccard.
+func QGramOverlap(s1 string, s2 string, q int, padded bool) float64 {
+	return qgramSimilarity(s1, s2, q, padded, QGramProfile.Overlap)
+}
+
+// QGramCosine computes the cosine similarity of the q-gram counts of two
+// strings, a float64 between 0 and 1 inclusive like QGramJaccard.
+func QGramCosine(s1 string, s2 string, q int, padded bool) float64 {
+	return qgramSimilarity(s1, s2, q, padded, QGramProfile.Cosine)
+}
+
+// compare the profiles of two strings, settling the case where neither has
+// any q-grams by whether the strings are equal
+func qgramSimilarity(s1 string, s2 string, q int, padded bool,
+	f func(QGramProfile, QGramProfile) float64) float64 {
+	p1 := NewQGramProfile(s1, q, padded)
+	p2 := NewQGramProfile(s2, q, padded)
+
+	if len(p1) == 0 && len(p2) == 0 {
+		if s1 == s2 {
+			return 1
+		}
+		return 0
+	}
+	return f(p1, p2)
+}
@@ -0,0 +1,70 @@
+package matchr
+
+import (
+	"math"
+	"testing"
+)
+
+var qgramdisttests = []struct {
+	s1     string
+	s2     string
+	q      int
+	padded bool
+	dist   int
+}{
+	{"", "", 2, false, 0},
+	{"abc", "abc", 2, false, 0},
+	{"abc", "abd", 2, false, 2},
+	{"abcd", "cdab", 2, false, 2},
+	{"abc", "abd", 2, true, 4},
+	// too short for any q-grams
+	{"a", "b", 2, false, 2},
+	{"a", "", 2, false, 1},
+	{"a", "a", 2, false, 0},
+	{"Schüßler", "Schußler", 2, false, 4},
+	{"aaaa", "aa", 2, false, 2},
+}
+
+func TestQGramDistance(t *testing.T) {
+	for _, tt := range qgramdisttests {
+		dist := QGramDistance(tt.s1, tt.s2, tt.q, tt.padded)
+		if dist != tt.dist {
+			t.Errorf("QGramDistance('%s', '%s', %d, %v) = %v, want %v", tt.s1, tt.s2, tt.q, tt.padded, dist, tt.dist)
+		}
+	}
+}
+
+var qgramsimtests = []struct {
+	s1     string
+	s2     string
+	q      int
+	padded bool
+	f      func(string, string, int, bool) float64
+	name   string
+	sim    float64
+}{
+	{"", "", 2, false, QGramJaccard, "QGramJaccard", 1.0},
+	{"a", "b", 2, false, QGramJaccard, "QGramJaccard", 0.0},
+	{"a", "a", 2, false, QGramJaccard, "QGramJaccard", 1.0},
+	{"night", "nacht", 2, false, QGramJaccard, "QGramJaccard", 1.0 / 7},
+	{"night", "nacht", 2, false, QGramSorensenDice, "QGramSorensenDice", 0.25},
+	{"night", "nacht", 2, false, QGramOverlap, "QGramOverlap", 0.25},
+	{"night", "nacht", 2, false, QGramCosine, "QGramCosine", 0.25},
+	{"abcd", "cdab", 2, false, QGramJaccard, "QGramJaccard", 0.5},
+	{"ab", "abcd", 2, false, QGramOverlap, "QGramOverlap", 1.0},
+	{"ab", "", 2, false, QGramOverlap, "QGramOverlap", 0.0},
+	{"aab", "ab", 1, false, QGramCosine, "QGramCosine", 3 / math.Sqrt(10)},
+	{"matchr", "matchr", 2, false, QGramCosine, "QGramCosine", 1},
+	{"Acme Holdings", "Holdings Acme", 3, false, QGramSorensenDice, "QGramSorensenDice", 16.0 / 22},
+	{"ab", "ab", 2, true, QGramJaccard, "QGramJaccard", 1.0},
+	{"ab", "ba", 2, true, QGramJaccard, "QGramJaccard", 0.0},
+}
+
+func TestQGramSimilarity(t *testing.T) {
+	for _, tt := range qgramsimtests {
+		sim := tt.f(tt.s1, tt.s2, tt.q, tt.padded)
+		if round(sim, 12) != round(tt.sim, 12) {
+			t.Errorf("%s('%s', '%s', %d, %v) = %v, want %v", tt.name, tt.s1, tt.s2, tt.q, tt.padded, sim, tt.sim)
+		}
+	}
+}
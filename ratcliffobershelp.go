@@ -0,0 +1,108 @@
+package matchr
+
+// RatcliffObershelp computes the Ratcliff/Obershelp similarity, also known
+// as gestalt pattern matching, between two strings. It finds the longest
+// common substring, then recursively does the same for the parts to its
+// left and to its right, and scores 2*matches/total, where matches is the
+// number of runes in all of the common substrings found and total is the
+// number of runes in both strings. The result is between 0 and 1 inclusive,
+// and two empty strings score 1.
+//
+// This reproduces Python's difflib.SequenceMatcher(None, s1, s2).ratio()
+// exactly, including its choice among equally long common substrings and
+// its "autojunk" heuristic: when s2 is at least 200 runes long, runes that
+// make up more than 1% of it cannot start a common substring, though they
+// can still extend one. Like Python's, the score is not symmetric: swapping
+// the strings can change it.
+//
+// See https://docs.python.org/3/library/difflib.html for more information.
+func RatcliffObershelp(s1 string, s2 string) float64 {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	total := len(r1) + len(r2)
+	if total == 0 {
+		return 1
+	}
+
+	// where each rune appears in r2, leaving out the popular ones
+	b2j := make(map[rune][]int)
+	for j, r := range r2 {
+		b2j[r] = append(b2j[r], j)
+	}
+	if len(r2) >= 200 {
+		popular := len(r2)/100 + 1
+		for r, positions := range b2j {
+			if len(positions) > popular {
+				delete(b2j, r)
+			}
+		}
+	}
+
+	// the regions of r1 and r2 left to match, as lo1, hi1, lo2, hi2
+	matches := 0
+	queue := [][4]int{{0, len(r1), 0, len(r2)}}
+	for len(queue) > 0 {
+		q := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		lo1, hi1, lo2, hi2 := q[0], q[1], q[2], q[3]
+
+		i, j, k := longestMatch(r1, r2, b2j, lo1, hi1, lo2, hi2)
+		if k == 0 {
+			continue
+		}
+
+		matches += k
+		if lo1 < i && lo2 < j {
+			queue = append(queue, [4]int{lo1, i, lo2, j})
+		}
+		if i+k < hi1 && j+k < hi2 {
+			queue = append(queue, [4]int{i + k, hi1, j + k, hi2})
+		}
+	}
+
+	return 2 * float64(matches) / float64(total)
+}
+
+// longestMatch finds the longest common substring of r1[lo1:hi1] and
+// r2[lo2:hi2] the way difflib's find_longest_match does, returning where it
+// starts in each and its length. Among equally long ones, it picks the one
+// that starts earliest in r1, then earliest in r2.
+func longestMatch(r1 []rune, r2 []rune, b2j map[rune][]int, lo1 int, hi1 int, lo2 int, hi2 int) (i int, j int, k int) {
+	i, j = lo1, lo2
+
+	// the length of the common substring ending at each position of r2 and
+	// at the previous position of r1
+	lengths := make(map[int]int)
+	for a := lo1; a < hi1; a++ {
+		next := make(map[int]int)
+		for _, b := range b2j[r1[a]] {
+			if b < lo2 {
+				continue
+			}
+			if b >= hi2 {
+				break
+			}
+
+			l := lengths[b-1] + 1
+			next[b] = l
+			if l > k {
+				i, j, k = a-l+1, b-l+1, l
+			}
+		}
+		lengths = next
+	}
+
+	// popular runes were left out of b2j, so extend over any on either side
+	for i > lo1 && j > lo2 && r1[i-1] == r2[j-1] {
+		i--
+		j--
+		k++
+	}
+	for i+k < hi1 && j+k < hi2 && r1[i+k] == r2[j+k] {
+		k++
+	}
+
+	return
+}
@@ -0,0 +1,43 @@
+package matchr
+
+import (
+	"strings"
+	"testing"
+)
+
+var rotests = []struct {
+	s1  string
+	s2  string
+	sim float64
+}{
+	{"abcd", "bcde", 0.75},
+	{"WIKIMEDIA", "WIKIMANIA", 14.0 / 18.0},
+	{"GESTALT PATTERN MATCHING", "GESTALT PRACTICE", 0.6},
+	{"Acme Widgets Inc", "ACME Widgets", 18.0 / 28.0},
+	{"qabxcd", "abycdf", 8.0 / 12.0},
+	// only the longer of two crossing matches counts
+	{"abxcd", "cdxab", 0.4},
+	// not symmetric
+	{"cbb", "babca", 0.25},
+	{"babca", "cbb", 0.5},
+	// popular runes in a long s2 do not start matches
+	{strings.Repeat("x", 10) + "ab", strings.Repeat("a", 150) + "b" + strings.Repeat("x", 60), 4.0 / 223.0},
+	// one empty, left
+	{"", "abc", 0.0},
+	// two empties
+	{"", "", 1.0},
+	{"abc", "xyz", 0.0},
+	// unicode stuff!
+	{"Schüßler", "Schüler", 14.0 / 15.0},
+	{"Schüßler", "Schüßler", 1.0},
+}
+
+// Ratcliff/Obershelp, checked against Python's difflib.SequenceMatcher.ratio
+func TestRatcliffObershelp(t *testing.T) {
+	for _, tt := range rotests {
+		sim := RatcliffObershelp(tt.s1, tt.s2)
+		if sim != tt.sim {
+			t.Errorf("RatcliffObershelp('%s', '%s') = %v, want %v", tt.s1, tt.s2, sim, tt.sim)
+		}
+	}
+}
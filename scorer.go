@@ -0,0 +1,153 @@
+package matchr
+
+import "unicode/utf8"
+
+// Scorer computes the edit distances and alignment scores of the package
+// while reusing the same rune slices and tables from one call to the next,
+// so that comparing one string against many does not allocate once the
+// buffers have grown to fit the longest strings seen. Each method returns
+// the same value as the package function of the same name.
+//
+// The zero value is ready to use. A Scorer is not safe for concurrent use;
+// give each goroutine its own, for example through a sync.Pool:
+//
+//	pool := sync.Pool{New: func() any { return new(matchr.Scorer) }}
+type Scorer struct {
+	r1     []rune
+	r2     []rune
+	ints   []int
+	floats []float64
+	words  []uint64
+	seen   map[rune]int
+	high   map[rune]uint64
+	peq    map[rune]int
+}
+
+// NewScorer returns a new Scorer.
+func NewScorer() *Scorer {
+	return new(Scorer)
+}
+
+// Levenshtein computes the Levenshtein distance between two strings.
+func (s *Scorer) Levenshtein(s1 string, s2 string) int {
+	l1 := utf8.RuneCountInString(s1)
+	l2 := utf8.RuneCountInString(s2)
+
+	// the shorter string is the pattern
+	if l1 > l2 {
+		s1, s2 = s2, s1
+		l1, l2 = l2, l1
+	}
+
+	if l1 == 0 {
+		return l2
+	}
+
+	if l1 <= 64 {
+		if s.high == nil {
+			s.high = make(map[rune]uint64)
+		} else {
+			for r := range s.high {
+				delete(s.high, r)
+			}
+		}
+		return myers64(s1, s2, l1, s.high)
+	}
+
+	if s.peq == nil {
+		s.peq = make(map[rune]int)
+	} else {
+		for r := range s.peq {
+			delete(s.peq, r)
+		}
+	}
+	n := myersRunes(s1, s.peq) + 2
+	return myersBlocked(s1, s2, l1, s.peq, s.wordBuffer(n*((l1+63)/64)))
+}
+
+// OSA computes the Optimal String Alignment distance between two strings.
+func (s *Scorer) OSA(s1 string, s2 string) int {
+	s.setRunes(s1, s2)
+	return osa(s.r1, s.r2, s.intBuffer(3*(len(s.r2)+1)))
+}
+
+// DamerauLevenshtein computes the Damerau-Levenshtein distance between two
+// strings.
+func (s *Scorer) DamerauLevenshtein(s1 string, s2 string) int {
+	s.setRunes(s1, s2)
+
+	if s.seen == nil {
+		s.seen = make(map[rune]int)
+	} else {
+		for r := range s.seen {
+			delete(s.seen, r)
+		}
+	}
+
+	rows := damerauLevenshteinRows(s.r1, s.seen)
+	return damerauLevenshtein(s.r1, s.r2, s.intBuffer(rows*(len(s.r2)+1)), s.seen)
+}
+
+// SmithWaterman computes the Smith-Waterman local sequence alignment score
+// of two strings.
+func (s *Scorer) SmithWaterman(s1 string, s2 string) float64 {
+	s.setRunes(s1, s2)
+	return smithWaterman(s.r1, s.r2, s.floatBuffer(2*len(s.r2)))
+}
+
+// LongestCommonSubsequence computes the length of the longest common
+// subsequence of two strings.
+func (s *Scorer) LongestCommonSubsequence(s1 string, s2 string) int {
+	s.setRunes(s1, s2)
+	return longestCommonSubsequence(s.r1, s.r2, s.intBuffer(2*(len(s.r2)+1)))
+}
+
+// index by code point, not byte
+func (s *Scorer) setRunes(s1 string, s2 string) {
+	s.r1 = appendRunes(s.r1[:0], s1)
+	s.r2 = appendRunes(s.r2[:0], s2)
+}
+
+// a zeroed int buffer of length n, grown only when it is too small
+func (s *Scorer) intBuffer(n int) []int {
+	if cap(s.ints) < n {
+		s.ints = make([]int, n)
+	}
+	buf := s.ints[:n]
+	for i := range buf {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// a zeroed float64 buffer of length n, grown only when it is too small
+func (s *Scorer) floatBuffer(n int) []float64 {
+	if cap(s.floats) < n {
+		s.floats = make([]float64, n)
+	}
+	buf := s.floats[:n]
+	for i := range buf {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// a zeroed uint64 buffer of length n, grown only when it is too small
+func (s *Scorer) wordBuffer(n int) []uint64 {
+	if cap(s.words) < n {
+		s.words = make([]uint64, n)
+	}
+	buf := s.words[:n]
+	for i := range buf {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// append the runes of str to dst without an intermediate allocation
+func appendRunes(dst []rune, str string) []rune {
+	for _, r := range str {
+		dst = append(dst, r)
+	}
+	return dst
+}
@@ -0,0 +1,90 @@
+package matchr
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestScorer(t *testing.T) {
+	s := NewScorer()
+
+	// run every table through the same Scorer so buffers get reused across
+	// strings of different lengths
+	for _, tt := range levtests {
+		if dist := s.Levenshtein(tt.s1, tt.s2); dist != tt.dist {
+			t.Errorf("Scorer.Levenshtein('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range osatests {
+		if dist := s.OSA(tt.s1, tt.s2); dist != tt.dist {
+			t.Errorf("Scorer.OSA('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range damlevtests {
+		if dist := s.DamerauLevenshtein(tt.s1, tt.s2); dist != tt.dist {
+			t.Errorf("Scorer.DamerauLevenshtein('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range swtests {
+		if dist := s.SmithWaterman(tt.s1, tt.s2); dist != tt.dist {
+			t.Errorf("Scorer.SmithWaterman('%s', '%s') = %v, want %v", tt.s1, tt.s2, dist, tt.dist)
+		}
+	}
+
+	for _, tt := range lcstests {
+		if length := s.LongestCommonSubsequence(tt.s1, tt.s2); length != tt.length {
+			t.Errorf("Scorer.LongestCommonSubsequence('%s', '%s') = %v, want %v", tt.s1, tt.s2, length, tt.length)
+		}
+	}
+}
+
+func TestScorerAllocations(t *testing.T) {
+	var s Scorer
+	s1, s2 := "Schüßler", "Schßüler"
+
+	// long enough for the blocked Levenshtein
+	l1, l2 := strings.Repeat(s1, 10), strings.Repeat(s2, 10)
+
+	// grow the buffers once
+	s.Levenshtein(s1, s2)
+	s.Levenshtein(l1, l2)
+	s.OSA(s1, s2)
+	s.DamerauLevenshtein(s1, s2)
+	s.SmithWaterman(s1, s2)
+	s.LongestCommonSubsequence(s1, s2)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Levenshtein(s1, s2)
+		s.Levenshtein(l1, l2)
+		s.OSA(s1, s2)
+		s.DamerauLevenshtein(s1, s2)
+		s.SmithWaterman(s1, s2)
+		s.LongestCommonSubsequence(s1, s2)
+	})
+	if allocs != 0 {
+		t.Errorf("Scorer allocated %v times per run, want 0", allocs)
+	}
+}
+
+func BenchmarkScorerLevenshtein(b *testing.B) {
+	s := NewScorer()
+	for n := 0; n < b.N; n++ {
+		for _, tt := range levtests {
+			_ = s.Levenshtein(tt.s1, tt.s2)
+		}
+	}
+}
+
+func BenchmarkScorerLevenshteinLong(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	s1 := randomLevString(r, 150)
+	s2 := randomLevString(r, 150)
+	s := NewScorer()
+	for n := 0; n < b.N; n++ {
+		_ = s.Levenshtein(s1, s2)
+	}
+}
@@ -0,0 +1,49 @@
+package matchr
+
+// ScoringScheme describes how the sequence alignment functions score an
+// alignment. Aligned runes score Match when they are equal and Mismatch when
+// they are not, unless Substitution holds a score for the pair. A gap of n
+// runes scores -(GapOpen + (n-1)*GapExtend), so gaps are affine: with
+// GapExtend below GapOpen, one long gap is penalized less than several
+// short ones. Setting GapOpen equal to GapExtend gives linear gaps.
+type ScoringScheme struct {
+	Match     float64
+	Mismatch  float64
+	GapOpen   float64
+	GapExtend float64
+
+	Substitution SubstitutionMatrix
+}
+
+// SubstitutionMatrix holds the score of aligning particular pairs of runes,
+// e.g. to score OCR-confusable glyphs closer to a match. The order of a pair
+// does not matter.
+type SubstitutionMatrix map[[2]rune]float64
+
+// DefaultScoringScheme returns the scheme SmithWaterman uses: +1 for a
+// match, -2 for a mismatch, and GAP_COST for every rune of a gap.
+func DefaultScoringScheme() ScoringScheme {
+	return ScoringScheme{
+		Match:     1.0,
+		Mismatch:  -2.0,
+		GapOpen:   GAP_COST,
+		GapExtend: GAP_COST,
+	}
+}
+
+// the score of aligning a with b
+func (s *ScoringScheme) score(a rune, b rune) float64 {
+	if s.Substitution != nil {
+		if v, ok := s.Substitution[[2]rune{a, b}]; ok {
+			return v
+		}
+		if v, ok := s.Substitution[[2]rune{b, a}]; ok {
+			return v
+		}
+	}
+
+	if a == b {
+		return s.Match
+	}
+	return s.Mismatch
+}
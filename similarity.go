@@ -0,0 +1,131 @@
+package matchr
+
+import "unicode/utf8"
+
+// Normalization selects how a raw distance or length is turned into a
+// similarity between 0 and 1 inclusive, with 0 indicating the two strings
+// are not at all similar and 1 indicating the two strings are exact
+// matches. Two empty strings are always exact matches.
+type Normalization int
+
+const (
+	// ByMaxLength divides by the rune length of the longer string. For the
+	// edit distances this is 1 - distance/max(len1, len2), since no
+	// transformation needs more edits than that.
+	ByMaxLength Normalization = iota
+
+	// BySumLength divides by the combined rune length of both strings. For
+	// the edit distances this is the Yujian-Bo normalization
+	// 1 - 2*distance/(len1+len2+distance), which keeps the triangle
+	// inequality. For LongestCommonSubsequence it is the Dice-style
+	// 2*length/(len1+len2).
+	BySumLength
+
+	// ByAlignmentLength divides by the number of columns in an optimal
+	// alignment of the two strings, i.e. the runes of both strings with each
+	// match, substitution or transposed rune counted once. For
+	// LongestCommonSubsequence this is length/(len1+len2-length).
+	ByAlignmentLength
+)
+
+// LevenshteinSimilarity computes the Levenshtein distance between two
+// strings and normalizes it to a similarity between 0 and 1 inclusive.
+func LevenshteinSimilarity(s1 string, s2 string, norm Normalization) float64 {
+	if norm == ByAlignmentLength {
+		distance, script := LevenshteinEditScript(s1, s2)
+		return normalizeDistance(distance, alignmentLength(script))
+	}
+	return normalizeEditDistance(s1, s2, Levenshtein(s1, s2), norm)
+}
+
+// OSASimilarity computes the Optimal String Alignment distance between two
+// strings and normalizes it to a similarity between 0 and 1 inclusive.
+func OSASimilarity(s1 string, s2 string, norm Normalization) float64 {
+	if norm == ByAlignmentLength {
+		distance, script := OSAEditScript(s1, s2)
+		return normalizeDistance(distance, alignmentLength(script))
+	}
+	return normalizeEditDistance(s1, s2, OSA(s1, s2), norm)
+}
+
+// DamerauLevenshteinSimilarity computes the Damerau-Levenshtein distance
+// between two strings and normalizes it to a similarity between 0 and 1
+// inclusive.
+func DamerauLevenshteinSimilarity(s1 string, s2 string, norm Normalization) float64 {
+	if norm == ByAlignmentLength {
+		distance, script := DamerauLevenshteinEditScript(s1, s2)
+		return normalizeDistance(distance, alignmentLength(script))
+	}
+	return normalizeEditDistance(s1, s2, DamerauLevenshtein(s1, s2), norm)
+}
+
+// HammingSimilarity computes the Hamming distance between two equal-length
+// strings and normalizes it to a similarity between 0 and 1 inclusive. Since
+// both strings have the same length, every normalization gives the same
+// result, 1 - distance/length.
+func HammingSimilarity(s1 string, s2 string) (similarity float64, err error) {
+	distance, err := Hamming(s1, s2)
+	if err != nil {
+		return
+	}
+
+	similarity = normalizeDistance(distance, utf8.RuneCountInString(s1))
+	return
+}
+
+// LongestCommonSubsequenceSimilarity computes the length of the longest
+// common subsequence of two strings and normalizes it to a similarity
+// between 0 and 1 inclusive.
+func LongestCommonSubsequenceSimilarity(s1 string, s2 string, norm Normalization) float64 {
+	length := LongestCommonSubsequence(s1, s2)
+	len1 := utf8.RuneCountInString(s1)
+	len2 := utf8.RuneCountInString(s2)
+
+	var total float64
+	switch norm {
+	case BySumLength:
+		total = float64(len1+len2) / 2
+	case ByAlignmentLength:
+		total = float64(len1 + len2 - length)
+	default:
+		total = float64(maxI(len1, len2))
+	}
+
+	if total == 0 {
+		return 1
+	}
+	return float64(length) / total
+}
+
+// normalize an edit distance by the length of one or both strings
+func normalizeEditDistance(s1 string, s2 string, distance int, norm Normalization) float64 {
+	len1 := utf8.RuneCountInString(s1)
+	len2 := utf8.RuneCountInString(s2)
+
+	if norm == BySumLength {
+		if len1+len2 == 0 {
+			return 1
+		}
+		return 1 - float64(2*distance)/float64(len1+len2+distance)
+	}
+	return normalizeDistance(distance, maxI(len1, len2))
+}
+
+// 1 - distance/total, treating a zero total as an exact match
+func normalizeDistance(distance int, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return 1 - float64(distance)/float64(total)
+}
+
+// the number of columns Align would render the script in
+func alignmentLength(script []Edit) (length int) {
+	for _, e := range script {
+		length++
+		if e.Op == OpTranspose {
+			length++
+		}
+	}
+	return
+}
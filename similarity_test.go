@@ -0,0 +1,59 @@
+package matchr
+
+import "testing"
+
+var simtests = []struct {
+	s1   string
+	s2   string
+	f    func(string, string, Normalization) float64
+	name string
+	norm Normalization
+	sim  float64
+}{
+	{"", "", LevenshteinSimilarity, "LevenshteinSimilarity", ByMaxLength, 1.0},
+	{"", "", LevenshteinSimilarity, "LevenshteinSimilarity", BySumLength, 1.0},
+	{"", "", LevenshteinSimilarity, "LevenshteinSimilarity", ByAlignmentLength, 1.0},
+	{"", "abc", LevenshteinSimilarity, "LevenshteinSimilarity", ByMaxLength, 0.0},
+	{"", "abc", LevenshteinSimilarity, "LevenshteinSimilarity", BySumLength, 0.0},
+	{"abc", "xyz", LevenshteinSimilarity, "LevenshteinSimilarity", ByMaxLength, 0.0},
+	{"car", "cars", LevenshteinSimilarity, "LevenshteinSimilarity", ByMaxLength, 0.75},
+	{"car", "cars", LevenshteinSimilarity, "LevenshteinSimilarity", BySumLength, 0.75},
+	{"car", "cars", LevenshteinSimilarity, "LevenshteinSimilarity", ByAlignmentLength, 0.75},
+	{"Schüßler", "Schübler", LevenshteinSimilarity, "LevenshteinSimilarity", ByMaxLength, 0.875},
+	{"library", "librayr", OSASimilarity, "OSASimilarity", ByMaxLength, 1 - 1.0/7},
+	{"ca", "abc", OSASimilarity, "OSASimilarity", ByMaxLength, 0.0},
+	{"ca", "abc", DamerauLevenshteinSimilarity, "DamerauLevenshteinSimilarity", ByMaxLength, 1 - 2.0/3},
+	{"ca", "abc", DamerauLevenshteinSimilarity, "DamerauLevenshteinSimilarity", BySumLength, 1 - 4.0/7},
+	{"ca", "abc", DamerauLevenshteinSimilarity, "DamerauLevenshteinSimilarity", ByAlignmentLength, 1 - 2.0/3},
+	{"coins", "cons", LongestCommonSubsequenceSimilarity, "LongestCommonSubsequenceSimilarity", ByMaxLength, 0.8},
+	{"coins", "cons", LongestCommonSubsequenceSimilarity, "LongestCommonSubsequenceSimilarity", BySumLength, 8.0 / 9},
+	{"coins", "cons", LongestCommonSubsequenceSimilarity, "LongestCommonSubsequenceSimilarity", ByAlignmentLength, 0.8},
+	{"", "hello", LongestCommonSubsequenceSimilarity, "LongestCommonSubsequenceSimilarity", ByMaxLength, 0.0},
+	{"", "", LongestCommonSubsequenceSimilarity, "LongestCommonSubsequenceSimilarity", BySumLength, 1.0},
+}
+
+func TestSimilarity(t *testing.T) {
+	for _, tt := range simtests {
+		sim := tt.f(tt.s1, tt.s2, tt.norm)
+		if round(sim, 12) != round(tt.sim, 12) {
+			t.Errorf("%s('%s', '%s', %v) = %v, want %v", tt.name, tt.s1, tt.s2, tt.norm, sim, tt.sim)
+		}
+	}
+}
+
+func TestHammingSimilarity(t *testing.T) {
+	for _, tt := range hamtests {
+		sim, err := HammingSimilarity(tt.s1, tt.s2)
+		if tt.err {
+			if err == nil {
+				t.Errorf("HammingSimilarity('%s', '%s') should throw an error", tt.s1, tt.s2)
+			}
+			continue
+		}
+
+		want := normalizeDistance(tt.dist, len([]rune(tt.s1)))
+		if sim != want {
+			t.Errorf("HammingSimilarity('%s', '%s') = %v, want %v", tt.s1, tt.s2, sim, want)
+		}
+	}
+}
@@ -14,12 +14,20 @@ func getCost(r1 []rune, r1Index int, r2 []rune, r2Index int) float64 {
 // two input strings. This was originally designed to find similar regions in
 // strings representing DNA or protein sequences.
 func SmithWaterman(s1 string, s2 string) float64 {
-	var cost float64
-
 	// index by code point, not byte
 	r1 := []rune(s1)
 	r2 := []rune(s2)
 
+	return smithWaterman(r1, r2, make([]float64, 2*len(r2)))
+}
+
+// smithWaterman computes the Smith-Waterman score of two rune slices. Only
+// the previous row of the score table is ever needed, so just that row and
+// the one being filled in are kept in rows. It must hold at least
+// 2*len(r2) values.
+func smithWaterman(r1 []rune, r2 []rune, rows []float64) float64 {
+	var cost float64
+
 	r1Len := len(r1)
 	r2Len := len(r2)
 
@@ -31,57 +39,269 @@ func SmithWaterman(s1 string, s2 string) float64 {
 		return float64(r1Len)
 	}
 
-	d := make([][]float64, r1Len)
-	for i := range d {
-		d[i] = make([]float64, r2Len)
-	}
+	// the row above and the one being filled in
+	prev := rows[0:r2Len]
+	curr := rows[r2Len : 2*r2Len]
 
 	var maxSoFar float64
-	for i := 0; i < r1Len; i++ {
+	for j := 0; j < r2Len; j++ {
 		// substitution cost
-		cost = getCost(r1, i, r2, 0)
-		if i == 0 {
-			d[0][0] = max(0.0, max(-GAP_COST, cost))
+		cost = getCost(r1, 0, r2, j)
+		if j == 0 {
+			prev[0] = max(0, max(-GAP_COST, cost))
 		} else {
-			d[i][0] = max(0.0, max(d[i-1][0]-GAP_COST, cost))
+			prev[j] = max(0, max(prev[j-1]-GAP_COST, cost))
 		}
 
 		// save if it is the biggest thus far
-		if d[i][0] > maxSoFar {
-			maxSoFar = d[i][0]
+		if prev[j] > maxSoFar {
+			maxSoFar = prev[j]
 		}
 	}
 
-	for j := 0; j < r2Len; j++ {
+	for i := 1; i < r1Len; i++ {
 		// substitution cost
-		cost = getCost(r1, 0, r2, j)
-		if j == 0 {
-			d[0][0] = max(0, max(-GAP_COST, cost))
-		} else {
-			d[0][j] = max(0, max(d[0][j-1]-GAP_COST, cost))
-		}
+		cost = getCost(r1, i, r2, 0)
+		curr[0] = max(0.0, max(prev[0]-GAP_COST, cost))
 
 		// save if it is the biggest thus far
-		if d[0][j] > maxSoFar {
-			maxSoFar = d[0][j]
+		if curr[0] > maxSoFar {
+			maxSoFar = curr[0]
 		}
-	}
 
-	for i := 1; i < r1Len; i++ {
 		for j := 1; j < r2Len; j++ {
 			cost = getCost(r1, i, r2, j)
 
 			// find the lowest cost
-			d[i][j] = max(
-				max(0, d[i-1][j]-GAP_COST),
-				max(d[i][j-1]-GAP_COST, d[i-1][j-1]+cost))
+			curr[j] = max(
+				max(0, prev[j]-GAP_COST),
+				max(curr[j-1]-GAP_COST, prev[j-1]+cost))
 
 			// save if it is the biggest thus far
-			if d[i][j] > maxSoFar {
-				maxSoFar = d[i][j]
+			if curr[j] > maxSoFar {
+				maxSoFar = curr[j]
 			}
 		}
+		prev, curr = curr, prev
 	}
 
 	return maxSoFar
 }
+
+// SmithWatermanWithScheme computes the Smith-Waterman local sequence
+// alignment score for the two input strings like SmithWaterman, but scored
+// with the given scheme, which allows affine gaps following Gotoh. With
+// DefaultScoringScheme() it returns the same value as SmithWaterman, except
+// that an empty string has no local alignment with anything and scores 0.
+//
+// The scheme is passed by value, so concurrent callers can each use their
+// own.
+func SmithWatermanWithScheme(s1 string, s2 string, scheme ScoringScheme) float64 {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	cols := len(r2) + 1
+
+	// h is the best score of an alignment ending at each cell, kept for the
+	// row above and the current one. f is the best score of one ending in a
+	// gap in s2 (a run of runes from s1 only), kept per column, and e is
+	// the best score of one ending in a gap in s1, kept along the row.
+	prev := make([]float64, cols)
+	curr := make([]float64, cols)
+	f := make([]float64, cols)
+	for j := range f {
+		f[j] = negInf
+	}
+
+	var maxSoFar float64
+	for i := 1; i <= len(r1); i++ {
+		e := negInf
+		curr[0] = 0
+		for j := 1; j < cols; j++ {
+			e = max(curr[j-1]-scheme.GapOpen, e-scheme.GapExtend)
+			f[j] = max(prev[j]-scheme.GapOpen, f[j]-scheme.GapExtend)
+
+			curr[j] = max(
+				max(0, prev[j-1]+scheme.score(r1[i-1], r2[j-1])),
+				max(e, f[j]))
+
+			// save if it is the biggest thus far
+			if curr[j] > maxSoFar {
+				maxSoFar = curr[j]
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return maxSoFar
+}
+
+// Alignment is an alignment of two strings along with its score. The
+// aligned regions are runes [Start1, End1) of s1 and [Start2, End2) of s2,
+// also given as Substring1 and Substring2; for a global alignment these are
+// the whole strings. Script lists the edits that
+// transform the first region into the second, with positions relative to
+// the whole strings, so Align(s1, s2, a.Script, gap) renders the alignment
+// for display.
+type Alignment struct {
+	Score float64
+
+	Start1 int
+	End1   int
+	Start2 int
+	End2   int
+
+	Substring1 string
+	Substring2 string
+
+	Script []Edit
+}
+
+// SmithWatermanAlignment finds the best local alignment of the two input
+// strings under the given scheme, as SmithWatermanWithScheme scores it, and
+// returns where it lies in each string. This is how to locate, say, a
+// company name embedded in a longer free-text field. If nothing aligns with
+// a positive score, the alignment is empty.
+//
+// Unlike SmithWatermanWithScheme, this keeps the whole score table in order
+// to trace the alignment back, so it takes memory proportional to the
+// product of the string lengths.
+func SmithWatermanAlignment(s1 string, s2 string, scheme ScoringScheme) (alignment Alignment) {
+	// index by code point, not byte
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	t := newAlignmentTables(len(r1), len(r2))
+	cols := t.cols
+
+	var bestI, bestJ int
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j < cols; j++ {
+			t.fill(r1, r2, i, j, &scheme, true)
+
+			if t.h[(i*cols)+j] > alignment.Score {
+				alignment.Score = t.h[(i*cols)+j]
+				bestI, bestJ = i, j
+			}
+		}
+	}
+
+	if alignment.Score == 0 {
+		return
+	}
+
+	alignment.Script, alignment.Start1, alignment.Start2 = t.traceback(r1, r2, bestI, bestJ, &scheme, true)
+	alignment.End1 = bestI
+	alignment.End2 = bestJ
+	alignment.Substring1 = string(r1[alignment.Start1:alignment.End1])
+	alignment.Substring2 = string(r2[alignment.Start2:alignment.End2])
+
+	return
+}
+
+// alignmentTables holds the full score tables of an alignment with affine
+// gaps, stored row by row: h for alignments ending anywhere, e for those
+// ending in a gap in s1 and f for those ending in a gap in s2.
+type alignmentTables struct {
+	cols int
+	h    []float64
+	e    []float64
+	f    []float64
+}
+
+func newAlignmentTables(len1 int, len2 int) *alignmentTables {
+	cols := len2 + 1
+	t := &alignmentTables{
+		cols: cols,
+		h:    make([]float64, (len1+1)*cols),
+		e:    make([]float64, (len1+1)*cols),
+		f:    make([]float64, (len1+1)*cols),
+	}
+
+	for n := range t.e {
+		t.e[n] = negInf
+		t.f[n] = negInf
+	}
+
+	return t
+}
+
+// fill in cell (i, j) from the ones above and to the left of it; a local
+// alignment may start afresh anywhere, so it never scores below zero
+func (t *alignmentTables) fill(r1 []rune, r2 []rune, i int, j int, scheme *ScoringScheme, local bool) {
+	cols := t.cols
+	c := (i * cols) + j
+
+	t.e[c] = max(t.h[c-1]-scheme.GapOpen, t.e[c-1]-scheme.GapExtend)
+	t.f[c] = max(t.h[c-cols]-scheme.GapOpen, t.f[c-cols]-scheme.GapExtend)
+	t.h[c] = max(t.h[c-cols-1]+scheme.score(r1[i-1], r2[j-1]), max(t.e[c], t.f[c]))
+
+	if local {
+		t.h[c] = max(0, t.h[c])
+	}
+}
+
+// traceback walks back from cell (i, j) to where the alignment starts,
+// which for a local alignment is the first cell scoring zero and for a
+// global one is the top left corner. It returns the edits in order and the
+// rune offsets in each string the alignment starts at.
+func (t *alignmentTables) traceback(r1 []rune, r2 []rune, i int, j int,
+	scheme *ScoringScheme, local bool) (script []Edit, start1 int, start2 int) {
+	cols := t.cols
+
+	// which table the path is currently in
+	const (
+		inH = iota
+		inE
+		inF
+	)
+	state := inH
+
+	for i > 0 || j > 0 {
+		c := (i * cols) + j
+
+		if state == inH {
+			if local && t.h[c] == 0 {
+				break
+			}
+
+			switch {
+			case i > 0 && j > 0 && t.h[c] == t.h[c-cols-1]+scheme.score(r1[i-1], r2[j-1]):
+				if r1[i-1] == r2[j-1] {
+					script = append(script, Edit{Op: OpMatch, Pos1: i - 1, Pos2: j - 1})
+				} else {
+					script = append(script, Edit{Op: OpSubstitute, Pos1: i - 1, Pos2: j - 1})
+				}
+				i--
+				j--
+			case j > 0 && t.h[c] == t.e[c]:
+				state = inE
+			default:
+				state = inF
+			}
+			continue
+		}
+
+		if state == inE {
+			script = append(script, Edit{Op: OpInsert, Pos1: i, Pos2: j - 1})
+			if t.e[c] == t.h[c-1]-scheme.GapOpen {
+				state = inH
+			}
+			j--
+		} else {
+			script = append(script, Edit{Op: OpDelete, Pos1: i - 1, Pos2: j})
+			if t.f[c] == t.h[c-cols]-scheme.GapOpen {
+				state = inH
+			}
+			i--
+		}
+	}
+
+	// the script was built from the end, so put it in order
+	for a, b := 0, len(script)-1; a < b; a, b = a+1, b-1 {
+		script[a], script[b] = script[b], script[a]
+	}
+
+	return script, i, j
+}
@@ -39,3 +39,107 @@ func TestSmithWaterman(t *testing.T) {
 		}
 	}
 }
+
+var swschemetests = []struct {
+	s1     string
+	s2     string
+	scheme ScoringScheme
+	score  float64
+}{
+	{"", "library", DefaultScoringScheme(), 0.0},
+	{"", "", DefaultScoringScheme(), 0.0},
+	// a single long gap costs less than several short ones with affine gaps
+	{"abcxxxxdef", "abcdef", ScoringScheme{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, 12 - 3.5},
+	{"abcxxxxdef", "abcdef", ScoringScheme{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 2}, 6},
+	// the substitution matrix scores look-alikes as matches
+	{"B0B", "BOB", ScoringScheme{Match: 1, Mismatch: -1, GapOpen: 1, GapExtend: 1,
+		Substitution: SubstitutionMatrix{{'O', '0'}: 1}}, 3},
+	{"B0B", "BOB", ScoringScheme{Match: 1, Mismatch: -1, GapOpen: 1, GapExtend: 1}, 1},
+}
+
+// Smith-Waterman with a scoring scheme
+func TestSmithWatermanWithScheme(t *testing.T) {
+	for _, tt := range swtests {
+		if tt.s1 == "" || tt.s2 == "" {
+			continue
+		}
+
+		score := SmithWatermanWithScheme(tt.s1, tt.s2, DefaultScoringScheme())
+		if score != tt.dist {
+			t.Errorf("SmithWatermanWithScheme('%s', '%s', DefaultScoringScheme()) = %v, want %v", tt.s1, tt.s2, score, tt.dist)
+		}
+	}
+
+	for _, tt := range swschemetests {
+		score := SmithWatermanWithScheme(tt.s1, tt.s2, tt.scheme)
+		if score != tt.score {
+			t.Errorf("SmithWatermanWithScheme('%s', '%s', %+v) = %v, want %v", tt.s1, tt.s2, tt.scheme, score, tt.score)
+		}
+	}
+}
+
+var swalignmenttests = []struct {
+	s1     string
+	s2     string
+	scheme ScoringScheme
+	score  float64
+	start1 int
+	end1   int
+	start2 int
+	end2   int
+	a1     string
+	a2     string
+}{
+	{"Invoice from Acme Widgets Inc, thanks", "ACME WIDGETS", ScoringScheme{Match: 1, Mismatch: -1, GapOpen: 1, GapExtend: 1,
+		Substitution: caseInsensitive()}, 12, 13, 25, 0, 12, "Acme Widgets", "ACME WIDGETS"},
+	{"xxabcxxxxdefyy", "abcdef", ScoringScheme{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 0.5}, 12 - 3.5, 2, 12, 0, 6, "abcxxxxdef", "abc----def"},
+	{"Schüßler", "Schüler", DefaultScoringScheme(), 6.5, 0, 8, 0, 7, "Schüßler", "Schü-ler"},
+	{"abc", "xyz", DefaultScoringScheme(), 0, 0, 0, 0, 0, "", ""},
+	{"", "abc", DefaultScoringScheme(), 0, 0, 0, 0, 0, "", ""},
+}
+
+// treats upper and lower case ASCII letters as matches
+func caseInsensitive() SubstitutionMatrix {
+	m := make(SubstitutionMatrix)
+	for r := 'a'; r <= 'z'; r++ {
+		m[[2]rune{r, r - 'a' + 'A'}] = 1
+	}
+	return m
+}
+
+// Smith-Waterman local alignment
+func TestSmithWatermanAlignment(t *testing.T) {
+	for _, tt := range swalignmenttests {
+		a := SmithWatermanAlignment(tt.s1, tt.s2, tt.scheme)
+		if a.Score != tt.score {
+			t.Errorf("SmithWatermanAlignment('%s', '%s') score = %v, want %v", tt.s1, tt.s2, a.Score, tt.score)
+		}
+		if a.Start1 != tt.start1 || a.End1 != tt.end1 || a.Start2 != tt.start2 || a.End2 != tt.end2 {
+			t.Errorf("SmithWatermanAlignment('%s', '%s') offsets = [%v, %v) [%v, %v), want [%v, %v) [%v, %v)",
+				tt.s1, tt.s2, a.Start1, a.End1, a.Start2, a.End2, tt.start1, tt.end1, tt.start2, tt.end2)
+		}
+
+		r1 := []rune(tt.s1)
+		r2 := []rune(tt.s2)
+		if a.Substring1 != string(r1[tt.start1:tt.end1]) || a.Substring2 != string(r2[tt.start2:tt.end2]) {
+			t.Errorf("SmithWatermanAlignment('%s', '%s') substrings = ('%s', '%s')", tt.s1, tt.s2, a.Substring1, a.Substring2)
+		}
+
+		a1, a2 := Align(tt.s1, tt.s2, a.Script, '-')
+		if a1 != tt.a1 || a2 != tt.a2 {
+			t.Errorf("Align of SmithWatermanAlignment('%s', '%s') = ('%s', '%s'), want ('%s', '%s')",
+				tt.s1, tt.s2, a1, a2, tt.a1, tt.a2)
+		}
+	}
+
+	for _, tt := range swtests {
+		if tt.s1 == "" || tt.s2 == "" {
+			continue
+		}
+
+		a := SmithWatermanAlignment(tt.s1, tt.s2, DefaultScoringScheme())
+		if a.Score != tt.dist {
+			t.Errorf("SmithWatermanAlignment('%s', '%s') score = %v, want %v", tt.s1, tt.s2, a.Score, tt.dist)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package matchr
+
+import (
+	"math"
+	"sort"
+)
+
+// SoftTFIDF compares multi-token strings by weighting each token by its
+// rarity in a corpus, so that tokens such as "LLC" or "Street" that appear
+// in many records count for little and distinctive ones count for a lot.
+// Unlike plain TF-IDF cosine similarity, tokens do not have to be spelled
+// identically to count: a token is matched with its most similar token in
+// the other string, provided the inner similarity exceeds a threshold.
+//
+// A SoftTFIDF is safe for concurrent use once built.
+//
+// See Cohen, Ravikumar and Fienberg, "A Comparison of String Distance
+// Metrics for Name-Matching Tasks" (2003).
+type SoftTFIDF struct {
+	threshold float64
+	sim       MetricFunc
+
+	// the number of documents in the corpus, and how many of them each
+	// token appears in
+	docs int
+	df   map[string]int
+}
+
+// NewSoftTFIDF learns token weights from the corpus, one document per
+// string. Tokens are matched when sim scores them above threshold; 0.9 is
+// the value recommended with the default, a nil sim, which uses JaroWinkler
+// without the long string tolerance adjustment.
+func NewSoftTFIDF(corpus []string, threshold float64, sim MetricFunc) *SoftTFIDF {
+	if sim == nil {
+		sim = jaroWinklerSim
+	}
+
+	s := &SoftTFIDF{
+		threshold: threshold,
+		sim:       sim,
+		df:        make(map[string]int),
+	}
+
+	for _, doc := range corpus {
+		s.docs++
+		for t := range tokenSet(doc) {
+			s.df[t]++
+		}
+	}
+
+	return s
+}
+
+// IDF returns the smoothed inverse document frequency of a token,
+// log((N+1)/(df+1)) + 1 for a corpus of N documents of which df contain the
+// token. Tokens never seen in the corpus get the highest weight.
+func (s *SoftTFIDF) IDF(token string) float64 {
+	return math.Log(float64(s.docs+1)/float64(s.df[token]+1)) + 1
+}
+
+// Compare computes the Soft TF-IDF similarity of two strings, a float64
+// between 0 and 1 inclusive with 0 indicating the two strings have no
+// tokens close enough to match and 1 indicating they are made up of the
+// same tokens. Each token is matched with at most one token of the other
+// string, the most similar pairs first, so that several tokens of s1 cannot
+// all claim the same token of s2.
+func (s *SoftTFIDF) Compare(s1 string, s2 string) float64 {
+	w1 := s.weights(s1)
+	w2 := s.weights(s2)
+
+	if len(w1) == 0 || len(w2) == 0 {
+		if len(w1) == len(w2) {
+			return 1
+		}
+		return 0
+	}
+
+	// every pair of tokens close enough to match
+	var pairs []softTFIDFPair
+	for t1 := range w1 {
+		for t2 := range w2 {
+			if sim := s.sim(t1, t2); sim > s.threshold {
+				pairs = append(pairs, softTFIDFPair{t1: t1, t2: t2, sim: sim})
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].sim != pairs[j].sim {
+			return pairs[i].sim > pairs[j].sim
+		}
+		if pairs[i].t1 != pairs[j].t1 {
+			return pairs[i].t1 < pairs[j].t1
+		}
+		return pairs[i].t2 < pairs[j].t2
+	})
+
+	var score float64
+	matched1 := make(map[string]bool)
+	matched2 := make(map[string]bool)
+	for _, p := range pairs {
+		if matched1[p.t1] || matched2[p.t2] {
+			continue
+		}
+		matched1[p.t1] = true
+		matched2[p.t2] = true
+		score += w1[p.t1] * w2[p.t2] * p.sim
+	}
+
+	// rounding can take exact matches ever so slightly past 1
+	return math.Min(score, 1)
+}
+
+type softTFIDFPair struct {
+	t1  string
+	t2  string
+	sim float64
+}
+
+// the unit-length TF-IDF vector of a string's tokens
+func (s *SoftTFIDF) weights(str string) map[string]float64 {
+	tf := make(map[string]int)
+	for _, t := range Tokenize(str) {
+		tf[t]++
+	}
+
+	weights := make(map[string]float64, len(tf))
+	var norm float64
+	for t, n := range tf {
+		w := math.Log(float64(n)+1) * s.IDF(t)
+		weights[t] = w
+		norm += w * w
+	}
+
+	norm = math.Sqrt(norm)
+	for t := range weights {
+		weights[t] /= norm
+	}
+
+	return weights
+}
@@ -0,0 +1,60 @@
+package matchr
+
+import (
+	"math"
+	"testing"
+)
+
+var softtfidfcorpus = []string{
+	"Acme Widgets LLC",
+	"Apex Gadgets LLC",
+	"Summit Tools LLC",
+	"Pinnacle Holdings LLC",
+	"Acme Holdings",
+	"123 Main Street",
+	"456 Oak Street",
+}
+
+func TestSoftTFIDF(t *testing.T) {
+	s := NewSoftTFIDF(softtfidfcorpus, 0.9, nil)
+
+	// a rare token weighs more than one found in most of the corpus
+	if s.IDF("LLC") >= s.IDF("Acme") || s.IDF("Acme") >= s.IDF("Zenith") {
+		t.Errorf("IDF('LLC') = %v, IDF('Acme') = %v, IDF('Zenith') = %v, want increasing",
+			s.IDF("LLC"), s.IDF("Acme"), s.IDF("Zenith"))
+	}
+	if idf := s.IDF("Zenith"); math.Abs(idf-(math.Log(8)+1)) > 1e-12 {
+		t.Errorf("IDF('Zenith') = %v, want %v", idf, math.Log(8)+1)
+	}
+
+	if sim := s.Compare("Acme Widgets LLC", "Acme Widgets LLC"); math.Abs(sim-1) > 1e-12 {
+		t.Errorf("Compare of identical strings = %v, want 1", sim)
+	}
+	if sim := s.Compare("", ""); sim != 1 {
+		t.Errorf("Compare('', '') = %v, want 1", sim)
+	}
+	if sim := s.Compare("Acme", ""); sim != 0 {
+		t.Errorf("Compare('Acme', '') = %v, want 0", sim)
+	}
+	if sim := s.Compare("Acme Widgets LLC", "Apex Gadgets Co"); sim != 0 {
+		t.Errorf("Compare of strings without close tokens = %v, want 0", sim)
+	}
+
+	// sharing the distinctive token matters more than sharing "LLC"
+	distinctive := s.Compare("Acme Widgets LLC", "Acme Widgets Inc")
+	common := s.Compare("Acme Widgets LLC", "Summit Tools LLC")
+	if distinctive <= common {
+		t.Errorf("Compare sharing rare tokens = %v, sharing 'LLC' = %v", distinctive, common)
+	}
+
+	// misspelled tokens still count, though for less than exact ones
+	misspelled := s.Compare("Acme Widgets LLC", "Acme Widgetts LLC")
+	if misspelled <= 0.9 || misspelled >= 1 {
+		t.Errorf("Compare with a misspelled token = %v, want between 0.9 and 1", misspelled)
+	}
+
+	// two tokens close to the same one do not both get credit for it
+	if sim := s.Compare("Jon John", "John"); sim >= 1 || sim <= 0 {
+		t.Errorf("Compare('Jon John', 'John') = %v, want between 0 and 1", sim)
+	}
+}
@@ -0,0 +1,128 @@
+package matchr
+
+import "sort"
+
+// SymSpell is a spelling correction index using the symmetric delete
+// approach of Wolf Garbe's SymSpell. When a term is added, every string
+// that can be made from it by deleting up to maxDistance runes is recorded
+// as pointing back to it. A lookup deletes runes from the input in the same
+// way, and any term sharing a deletion variant with the input is a
+// candidate. Two strings within n edits of each other always share a
+// variant made with at most n deletions each, so no correction is missed,
+// while only the candidates ever get a full DamerauLevenshtein comparison.
+//
+// See https://github.com/wolfgarbe/SymSpell for more information.
+type SymSpell struct {
+	maxDistance int
+
+	// the terms each deletion variant can be made from
+	deletes map[string][]string
+
+	// how often each term occurs
+	frequencies map[string]int
+}
+
+// Suggestion is a dictionary term offered as a correction by
+// SymSpell.Lookup.
+type Suggestion struct {
+	Term      string
+	Distance  int
+	Frequency int
+}
+
+// NewSymSpell returns an empty SymSpell index able to look up corrections up
+// to maxDistance edits away.
+func NewSymSpell(maxDistance int) *SymSpell {
+	return &SymSpell{
+		maxDistance: maxDistance,
+		deletes:     make(map[string][]string),
+		frequencies: make(map[string]int),
+	}
+}
+
+// Len returns the number of distinct terms in the index.
+func (s *SymSpell) Len() int {
+	return len(s.frequencies)
+}
+
+// Add records that the term occurs frequency more times. Adding a term that
+// is already present only adds to its frequency.
+func (s *SymSpell) Add(term string, frequency int) {
+	if _, ok := s.frequencies[term]; ok {
+		s.frequencies[term] += frequency
+		return
+	}
+	s.frequencies[term] = frequency
+
+	for variant := range deletionVariants(term, s.maxDistance) {
+		s.deletes[variant] = append(s.deletes[variant], term)
+	}
+}
+
+// Lookup returns the terms within maxDistance edits of the input, which is
+// capped at the distance the index was built for. Suggestions come closest
+// first, then most frequent first, then in alphabetical order.
+func (s *SymSpell) Lookup(input string, maxDistance int) (suggestions []Suggestion) {
+	if maxDistance > s.maxDistance {
+		maxDistance = s.maxDistance
+	}
+	if maxDistance < 0 {
+		return
+	}
+
+	checked := make(map[string]bool)
+	for variant := range deletionVariants(input, maxDistance) {
+		for _, term := range s.deletes[variant] {
+			if checked[term] {
+				continue
+			}
+			checked[term] = true
+
+			if d := DamerauLevenshtein(input, term); d <= maxDistance {
+				suggestions = append(suggestions, Suggestion{
+					Term:      term,
+					Distance:  d,
+					Frequency: s.frequencies[term],
+				})
+			}
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Frequency != b.Frequency {
+			return a.Frequency > b.Frequency
+		}
+		return a.Term < b.Term
+	})
+
+	return
+}
+
+// every string made by deleting up to n runes from s, s itself included
+func deletionVariants(s string, n int) map[string]bool {
+	variants := map[string]bool{s: true}
+
+	// each round deletes one more rune from the previous round's variants
+	round := []string{s}
+	for d := 0; d < n; d++ {
+		var next []string
+		for _, v := range round {
+			// index by code point, not byte
+			r := []rune(v)
+			for i := range r {
+				deleted := string(r[:i]) + string(r[i+1:])
+				if !variants[deleted] {
+					variants[deleted] = true
+					next = append(next, deleted)
+				}
+			}
+		}
+		round = next
+	}
+
+	return variants
+}
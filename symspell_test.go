@@ -0,0 +1,76 @@
+package matchr
+
+import (
+	"reflect"
+	"testing"
+)
+
+var symspellterms = []struct {
+	term      string
+	frequency int
+}{
+	{"widget", 50},
+	{"widgets", 20},
+	{"gadget", 40},
+	{"gizmo", 10},
+	{"gismo", 1},
+	{"Schüßler", 5},
+	{"abc", 3},
+}
+
+var symspelltests = []struct {
+	input       string
+	maxDistance int
+	suggestions []Suggestion
+}{
+	{"widget", 0, []Suggestion{{"widget", 0, 50}}},
+	{"wdiget", 1, []Suggestion{{"widget", 1, 50}}},
+	{"widgest", 2, []Suggestion{{"widget", 1, 50}, {"widgets", 1, 20}}},
+	{"gizmo", 1, []Suggestion{{"gizmo", 0, 10}, {"gismo", 1, 1}}},
+	{"gimso", 2, []Suggestion{{"gismo", 1, 1}, {"gizmo", 2, 10}}},
+	{"Schüler", 1, []Suggestion{{"Schüßler", 1, 5}}},
+	{"ca", 2, []Suggestion{{"abc", 2, 3}}},
+	{"nothing", 2, nil},
+	{"widget", -1, nil},
+}
+
+func TestSymSpell(t *testing.T) {
+	s := NewSymSpell(2)
+	for _, tt := range symspellterms {
+		s.Add(tt.term, tt.frequency)
+	}
+	s.Add("widget", 0)
+
+	if s.Len() != len(symspellterms) {
+		t.Errorf("SymSpell.Len() = %v, want %v", s.Len(), len(symspellterms))
+	}
+
+	for _, tt := range symspelltests {
+		suggestions := s.Lookup(tt.input, tt.maxDistance)
+		if !reflect.DeepEqual(suggestions, tt.suggestions) {
+			t.Errorf("SymSpell.Lookup('%s', %d) = %v, want %v", tt.input, tt.maxDistance, suggestions, tt.suggestions)
+		}
+	}
+}
+
+// Every term within the distance must be found, just as comparing against
+// each term would.
+func TestSymSpellExhaustive(t *testing.T) {
+	s := NewSymSpell(2)
+	for _, tt := range symspellterms {
+		s.Add(tt.term, tt.frequency)
+	}
+
+	for _, input := range []string{"wigdet", "gadgets", "gzmo", "Schußler", "bca", "x"} {
+		want := 0
+		for _, tt := range symspellterms {
+			if DamerauLevenshtein(input, tt.term) <= 2 {
+				want++
+			}
+		}
+
+		if got := len(s.Lookup(input, 2)); got != want {
+			t.Errorf("SymSpell.Lookup('%s', 2) found %v terms, want %v", input, got, want)
+		}
+	}
+}
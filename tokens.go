@@ -0,0 +1,81 @@
+package matchr
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits a string into its tokens: the runs of letters and digits
+// between whitespace, punctuation and symbols.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// TokenSortSimilarity compares two strings without regard to the order of
+// their tokens. Both strings are tokenized, their tokens sorted and joined
+// back together with single spaces, and the results are compared with sim,
+// which should be a similarity between 0 and 1 such as JaroWinkler or a
+// normalized Levenshtein. That way "Acme Holdings Inc" and "Inc Acme
+// Holdings" are exact matches. Tokens are compared as they are, so fold the
+// case of both strings first for a case-insensitive comparison.
+func TokenSortSimilarity(s1 string, s2 string, sim MetricFunc) float64 {
+	t1 := Tokenize(s1)
+	t2 := Tokenize(s2)
+	sort.Strings(t1)
+	sort.Strings(t2)
+
+	return sim(strings.Join(t1, " "), strings.Join(t2, " "))
+}
+
+// TokenSetSimilarity compares two strings by the sets of their tokens, so
+// that neither the order of the tokens, repeated tokens, nor extra tokens in
+// one of the strings count against them. The tokens the strings share are
+// sorted and joined into a common core, the remaining tokens of each string
+// are appended to the core in sorted order, and the best score sim gives
+// any two of the three is the result. sim should be a similarity between 0
+// and 1, as for TokenSortSimilarity. A string without any tokens has
+// nothing in common with one that has some.
+func TokenSetSimilarity(s1 string, s2 string, sim MetricFunc) float64 {
+	set1 := tokenSet(s1)
+	set2 := tokenSet(s2)
+
+	// otherwise the empty core would be compared with the empty string
+	if (len(set1) == 0) != (len(set2) == 0) {
+		return 0
+	}
+
+	var common, only1, only2 []string
+	for t := range set1 {
+		if set2[t] {
+			common = append(common, t)
+		} else {
+			only1 = append(only1, t)
+		}
+	}
+	for t := range set2 {
+		if !set1[t] {
+			only2 = append(only2, t)
+		}
+	}
+	sort.Strings(common)
+	sort.Strings(only1)
+	sort.Strings(only2)
+
+	core := strings.Join(common, " ")
+	full1 := strings.TrimSpace(core + " " + strings.Join(only1, " "))
+	full2 := strings.TrimSpace(core + " " + strings.Join(only2, " "))
+
+	return max(sim(core, full1), max(sim(core, full2), sim(full1, full2)))
+}
+
+// the distinct tokens of a string
+func tokenSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, t := range Tokenize(s) {
+		set[t] = true
+	}
+	return set
+}
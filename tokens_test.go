@@ -0,0 +1,62 @@
+package matchr
+
+import (
+	"reflect"
+	"testing"
+)
+
+var tokenizetests = []struct {
+	s      string
+	tokens []string
+}{
+	{"", []string{}},
+	{"Acme Holdings, Inc.", []string{"Acme", "Holdings", "Inc"}},
+	{"  O'Brien-Smith\t& Sons ", []string{"O", "Brien", "Smith", "Sons"}},
+	{"Schüßler 42", []string{"Schüßler", "42"}},
+}
+
+func TestTokenize(t *testing.T) {
+	for _, tt := range tokenizetests {
+		tokens := Tokenize(tt.s)
+		if !reflect.DeepEqual(tokens, tt.tokens) {
+			t.Errorf("Tokenize('%s') = %#v, want %#v", tt.s, tokens, tt.tokens)
+		}
+	}
+}
+
+func levenshteinSimilarityByMax(s1 string, s2 string) float64 {
+	return LevenshteinSimilarity(s1, s2, ByMaxLength)
+}
+
+var tokensimtests = []struct {
+	s1   string
+	s2   string
+	f    func(string, string, MetricFunc) float64
+	name string
+	sim  float64
+}{
+	{"Acme Holdings Inc", "Inc Acme Holdings", TokenSortSimilarity, "TokenSortSimilarity", 1.0},
+	{"Acme Holdings, Inc.", "Inc Acme Holdings", TokenSortSimilarity, "TokenSortSimilarity", 1.0},
+	{"Acme Holdings", "Holdings Acme Inc", TokenSortSimilarity, "TokenSortSimilarity", 13.0 / 17},
+	{"Acme Holdings", "Holdings Acme Inc", TokenSetSimilarity, "TokenSetSimilarity", 1.0},
+	{"Acme Acme Holdings", "Holdings Acme", TokenSetSimilarity, "TokenSetSimilarity", 1.0},
+	{"Acme Holdings", "Apex Ltd", TokenSetSimilarity, "TokenSetSimilarity", levenshteinSimilarityByMax("Acme Holdings", "Apex Ltd")},
+	{"", "", TokenSetSimilarity, "TokenSetSimilarity", 1.0},
+	{"", "Acme Holdings", TokenSetSimilarity, "TokenSetSimilarity", 0.0},
+	{"Acme Holdings", "---", TokenSetSimilarity, "TokenSetSimilarity", 0.0},
+}
+
+func TestTokenSimilarity(t *testing.T) {
+	for _, tt := range tokensimtests {
+		sim := tt.f(tt.s1, tt.s2, levenshteinSimilarityByMax)
+		if round(sim, 12) != round(tt.sim, 12) {
+			t.Errorf("%s('%s', '%s') = %v, want %v", tt.name, tt.s1, tt.s2, sim, tt.sim)
+		}
+	}
+
+	// any registered similarity can be used
+	sim := TokenSortSimilarity("Acme Holdings Inc", "Inc Acme Holdings", Metrics["jarowinkler"].Compare)
+	if sim != 1 {
+		t.Errorf("TokenSortSimilarity with jarowinkler = %v, want 1", sim)
+	}
+}
@@ -0,0 +1,130 @@
+package matchr
+
+import "sort"
+
+// Trie is a set of terms stored by rune, so that terms sharing a prefix
+// share the nodes for it. Together with a LevenshteinAutomaton it answers
+// fuzzy term and prefix searches while only visiting the branches that can
+// still match.
+type Trie struct {
+	root trieNode
+	size int
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	term     bool
+}
+
+// NewTrie returns a Trie holding the given terms.
+func NewTrie(terms ...string) *Trie {
+	t := new(Trie)
+	for _, term := range terms {
+		t.Add(term)
+	}
+	return t
+}
+
+// Len returns the number of distinct terms in the trie.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Add inserts a term into the trie.
+func (t *Trie) Add(term string) {
+	node := &t.root
+	for _, r := range term {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = new(trieNode)
+			node.children[r] = child
+		}
+		node = child
+	}
+
+	if !node.term {
+		node.term = true
+		t.size++
+	}
+}
+
+// Contains tells whether the term is in the trie.
+func (t *Trie) Contains(term string) bool {
+	node := &t.root
+	for _, r := range term {
+		node = node.children[r]
+		if node == nil {
+			return false
+		}
+	}
+	return node.term
+}
+
+// FuzzySearch returns every term the automaton accepts, i.e. every term
+// within its k edits of its word, in ascending order.
+func (t *Trie) FuzzySearch(a *LevenshteinAutomaton) (terms []string) {
+	t.search(a, false, func(term string) {
+		terms = append(terms, term)
+	})
+	sort.Strings(terms)
+	return
+}
+
+// FuzzyPrefixSearch returns every term that starts with a string the
+// automaton accepts, in ascending order. This is what autocompletion wants:
+// the terms that the input, typos and all, could be the beginning of.
+func (t *Trie) FuzzyPrefixSearch(a *LevenshteinAutomaton) (terms []string) {
+	t.search(a, true, func(term string) {
+		terms = append(terms, term)
+	})
+	sort.Strings(terms)
+	return
+}
+
+// walk the trie alongside the automaton, abandoning every branch that
+// reaches the dead state
+func (t *Trie) search(a *LevenshteinAutomaton, prefix bool, found func(string)) {
+	start := a.Start()
+	if start < 0 {
+		return
+	}
+
+	var path []rune
+	var visit func(node *trieNode, state int)
+	visit = func(node *trieNode, state int) {
+		matched := a.IsMatch(state)
+		if prefix && matched {
+			collectTerms(node, path, found)
+			return
+		}
+
+		if node.term && matched {
+			found(string(path))
+		}
+
+		for r, child := range node.children {
+			next := a.Step(state, r)
+			if next < 0 {
+				continue
+			}
+			path = append(path, r)
+			visit(child, next)
+			path = path[:len(path)-1]
+		}
+	}
+
+	visit(&t.root, start)
+}
+
+// report every term at or below the node
+func collectTerms(node *trieNode, path []rune, found func(string)) {
+	if node.term {
+		found(string(path))
+	}
+	for r, child := range node.children {
+		collectTerms(child, append(path, r), found)
+	}
+}
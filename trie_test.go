@@ -0,0 +1,49 @@
+package matchr
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTrieFuzzySearch(t *testing.T) {
+	trie := NewTrie(automatonwords...)
+	trie.Add("cat")
+
+	if trie.Len() != len(automatonwords) {
+		t.Errorf("Trie.Len() = %v, want %v", trie.Len(), len(automatonwords))
+	}
+	if !trie.Contains("candy") || trie.Contains("cand") {
+		t.Errorf("Trie.Contains does not agree with the terms added")
+	}
+
+	for _, word := range []string{"aple", "cat", "ban", "Schüsler", "zzz"} {
+		for k := 0; k <= 2; k++ {
+			a, _ := NewLevenshteinAutomaton(word, k, true)
+
+			var want, wantPrefix []string
+			for _, w := range automatonwords {
+				if OSA(word, w) <= k {
+					want = append(want, w)
+				}
+
+				runes := []rune(w)
+				for i := 0; i <= len(runes); i++ {
+					if OSA(word, string(runes[:i])) <= k {
+						wantPrefix = append(wantPrefix, w)
+						break
+					}
+				}
+			}
+			sort.Strings(want)
+			sort.Strings(wantPrefix)
+
+			if got := trie.FuzzySearch(a); !reflect.DeepEqual(got, want) {
+				t.Errorf("Trie.FuzzySearch('%s', %d) = %v, want %v", word, k, got, want)
+			}
+			if got := trie.FuzzyPrefixSearch(a); !reflect.DeepEqual(got, wantPrefix) {
+				t.Errorf("Trie.FuzzyPrefixSearch('%s', %d) = %v, want %v", word, k, got, wantPrefix)
+			}
+		}
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// a score lower than any alignment can reach
+var negInf = math.Inf(-1)
+
 // min of two integers
 func min(a int, b int) (res int) {
 	if a < b {
@@ -27,6 +30,26 @@ func maxI(a int, b int) (res int) {
 	return
 }
 
+// min of two float64s
+func minF(a float64, b float64) (res float64) {
+	if a < b {
+		res = a
+	} else {
+		res = b
+	}
+
+	return
+}
+
+// absolute value of an integer
+func absI(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
+
 // max of two float64s
 func max(a float64, b float64) (res float64) {
 	if a < b {